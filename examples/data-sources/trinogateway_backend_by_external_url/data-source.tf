@@ -0,0 +1,3 @@
+data "trinogateway_backend_by_external_url" "adhoc" {
+  external_url = "https://trino-adhoc.example.com"
+}
@@ -0,0 +1,3 @@
+data "trinogateway_backend_health" "trino_1" {
+  name = "trino-1"
+}
@@ -0,0 +1,3 @@
+data "trinogateway_backend_history" "trino_1" {
+  name = "trino-1"
+}
@@ -0,0 +1,5 @@
+data "trinogateway_backend_validation" "trino_1" {
+  name          = "trino-1"
+  proxy_to      = "http://localhost:8081"
+  routing_group = "adhoc"
+}
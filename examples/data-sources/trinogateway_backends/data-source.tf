@@ -0,0 +1,3 @@
+data "trinogateway_backends" "adhoc" {
+  routing_group = "adhoc"
+}
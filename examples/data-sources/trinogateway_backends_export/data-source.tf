@@ -0,0 +1,6 @@
+data "trinogateway_backends_export" "backup" {}
+
+resource "local_file" "backends_backup" {
+  filename = "${path.module}/backends.json"
+  content  = data.trinogateway_backends_export.backup.json
+}
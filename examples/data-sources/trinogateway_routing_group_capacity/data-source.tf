@@ -0,0 +1,3 @@
+data "trinogateway_routing_group_capacity" "adhoc" {
+  routing_group = "adhoc"
+}
@@ -0,0 +1 @@
+data "trinogateway_routing_groups" "all" {}
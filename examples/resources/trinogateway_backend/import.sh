@@ -0,0 +1,10 @@
+# Backend can be imported by name
+terraform import trinogateway_backend.example trino-1
+
+# or by routing_group/name, which also checks that backend belongs to the routing group.
+# Id is looked up as backend name first, so backends with slash in name are imported by name
+terraform import trinogateway_backend.example adhoc/trino-1
+
+# external_url equal to proxy_to is imported according to provider import_external_url:
+# "defaulted" follows external_url_default, so it is null in state with external_url_default = "null",
+# "explicit" always keeps gateway value, for configurations setting external_url = proxy_to
@@ -0,0 +1 @@
+terraform import trinogateway_backend_membership.example trino-1
@@ -0,0 +1,4 @@
+resource "trinogateway_backend_membership" "example" {
+  name          = "trino-1"
+  routing_group = "etl"
+}
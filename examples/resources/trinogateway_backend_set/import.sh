@@ -0,0 +1 @@
+terraform import trinogateway_backend_set.all backends
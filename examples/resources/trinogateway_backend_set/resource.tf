@@ -0,0 +1,17 @@
+resource "trinogateway_backend_set" "all" {
+  backends = [
+    {
+      name          = "adhoc-1"
+      proxy_to      = "http://trino-adhoc-1:8080"
+      active        = true
+      routing_group = "adhoc"
+    },
+    {
+      name          = "etl-1"
+      proxy_to      = "http://trino-etl-1:8080"
+      active        = true
+      routing_group = "etl"
+      external_url  = "https://trino-etl.example.com"
+    },
+  ]
+}
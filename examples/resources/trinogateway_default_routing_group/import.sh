@@ -0,0 +1 @@
+terraform import trinogateway_default_routing_group.this default
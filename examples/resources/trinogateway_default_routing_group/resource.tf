@@ -0,0 +1,3 @@
+resource "trinogateway_default_routing_group" "this" {
+  routing_group = "adhoc"
+}
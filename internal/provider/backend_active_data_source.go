@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendActiveDataSource{}
+
+func NewBackendActiveDataSource() datasource.DataSource {
+	return &BackendActiveDataSource{}
+}
+
+// BackendActiveDataSource defines the data source implementation.
+type BackendActiveDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendActiveDataSourceModel describes the data source data model.
+type BackendActiveDataSourceModel struct {
+	Name   types.String `tfsdk:"name"`
+	Active types.Bool   `tfsdk:"active"`
+}
+
+func (d *BackendActiveDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_active"
+}
+
+func (d *BackendActiveDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Activation status of backend",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of backend",
+				Required:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Backend activation",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BackendActiveDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendActiveDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendActiveDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backend, err := d.client.GetBackend(ctx, data.Name.ValueString())
+	if errors.Is(err, trinogatewayclient.ErrBackendNotFound) {
+		resp.Diagnostics.AddError("Backend not found", fmt.Sprintf("Backend %q not found", data.Name.ValueString()))
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get backend", err)
+		return
+	}
+
+	data.Active = types.BoolValue(backend.Active)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
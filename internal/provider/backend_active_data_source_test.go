@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBackendActiveDataSource(t *testing.T) {
+	client := backendsGateway(t, testBackends)
+	tests := []struct {
+		name       string
+		wantActive bool
+		wantErr    bool
+	}{
+		{name: "adhoc-1", wantActive: true},
+		{name: "etl-1", wantActive: false},
+		{name: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := readDataSource(t, NewBackendActiveDataSource(), &TrinoGatewayProviderData{Client: client}, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, tt.name),
+			})
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", resp.Diagnostics, tt.wantErr)
+			}
+			if tt.wantErr {
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Backend not found" {
+					t.Fatalf("got error %q, want not found", summary)
+				}
+				return
+			}
+			var data BackendActiveDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Active.ValueBool() != tt.wantActive {
+				t.Fatalf("got active %v, want %v", data.Active.ValueBool(), tt.wantActive)
+			}
+		})
+	}
+}
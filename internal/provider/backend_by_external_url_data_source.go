@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendByExternalUrlDataSource{}
+
+func NewBackendByExternalUrlDataSource() datasource.DataSource {
+	return &BackendByExternalUrlDataSource{}
+}
+
+// BackendByExternalUrlDataSource defines the data source implementation.
+type BackendByExternalUrlDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendByExternalUrlDataSourceModel describes the data source data model.
+type BackendByExternalUrlDataSourceModel struct {
+	ExternalUrl  types.String `tfsdk:"external_url"`
+	Name         types.String `tfsdk:"name"`
+	ProxyTo      types.String `tfsdk:"proxy_to"`
+	Active       types.Bool   `tfsdk:"active"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+}
+
+func (d *BackendByExternalUrlDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_by_external_url"
+}
+
+func (d *BackendByExternalUrlDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Backend with given external url. Fails if there is no such backend or there are several of them",
+
+		Attributes: map[string]schema.Attribute{
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "External backend url. Trailing slash after host is ignored",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of backend",
+				Computed:            true,
+			},
+			"proxy_to": schema.StringAttribute{
+				MarkdownDescription: "Backend url",
+				Computed:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Backend activation",
+				Computed:            true,
+			},
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BackendByExternalUrlDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendByExternalUrlDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendByExternalUrlDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backends, err := d.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+
+	var matched []*trinogatewayclient.Backend
+	for _, backend := range backends {
+		if urlsEquivalent(backend.ExternalUrl, data.ExternalUrl.ValueString()) {
+			matched = append(matched, backend)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Backend not found",
+			fmt.Sprintf("There is no backend with external url %q", data.ExternalUrl.ValueString()),
+		)
+		return
+	case 1:
+	default:
+		names := make([]string, 0, len(matched))
+		for _, backend := range matched {
+			names = append(names, backend.Name)
+		}
+		resp.Diagnostics.AddError(
+			"Several backends found",
+			fmt.Sprintf("Backends %s share external url %q", strings.Join(names, ", "), data.ExternalUrl.ValueString()),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(matched[0].Name)
+	data.ProxyTo = types.StringValue(matched[0].ProxyTo)
+	data.Active = types.BoolValue(matched[0].Active)
+	data.RoutingGroup = types.StringValue(matched[0].RoutingGroup)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
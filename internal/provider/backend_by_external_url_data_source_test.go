@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBackendByExternalUrlDataSource(t *testing.T) {
+	client := backendsGateway(t, `[
+	{"name":"etl-1","proxyTo":"http://etl-1:8080","routingGroup":"etl","active":false,"externalUrl":"https://etl.example.com"},
+	{"name":"adhoc-1","proxyTo":"http://adhoc-1:8080","routingGroup":"adhoc","active":true,"externalUrl":"https://adhoc.example.com"},
+	{"name":"adhoc-2","proxyTo":"http://adhoc-2:8080","routingGroup":"adhoc","active":true,"externalUrl":"https://adhoc.example.com/"}
+]`)
+	tests := []struct {
+		name        string
+		externalUrl string
+		wantName    string
+		wantErr     string
+	}{
+		{name: "exact", externalUrl: "https://etl.example.com", wantName: "etl-1"},
+		{name: "trailing slash", externalUrl: "https://etl.example.com/", wantName: "etl-1"},
+		{name: "missing", externalUrl: "https://other.example.com", wantErr: "Backend not found"},
+		{name: "several", externalUrl: "https://adhoc.example.com", wantErr: "Several backends found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := readDataSource(t, NewBackendByExternalUrlDataSource(), &TrinoGatewayProviderData{Client: client}, map[string]tftypes.Value{
+				"external_url": tftypes.NewValue(tftypes.String, tt.externalUrl),
+			})
+			if tt.wantErr != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("want error %q", tt.wantErr)
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tt.wantErr {
+					t.Fatalf("got error %q, want %q", summary, tt.wantErr)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var data BackendByExternalUrlDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Name.ValueString() != tt.wantName {
+				t.Fatalf("got backend %q, want %q", data.Name.ValueString(), tt.wantName)
+			}
+			if data.ProxyTo.ValueString() != "http://etl-1:8080" || data.RoutingGroup.ValueString() != "etl" || data.Active.ValueBool() {
+				t.Fatalf("unexpected backend fields: %+v", data)
+			}
+		})
+	}
+}
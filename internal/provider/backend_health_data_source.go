@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendHealthDataSource{}
+
+func NewBackendHealthDataSource() datasource.DataSource {
+	return &BackendHealthDataSource{}
+}
+
+// BackendHealthDataSource defines the data source implementation.
+type BackendHealthDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendHealthDataSourceModel describes the data source data model.
+type BackendHealthDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Healthy     types.Bool   `tfsdk:"healthy"`
+	LatencyMs   types.Int64  `tfsdk:"latency_ms"`
+	LastChecked types.String `tfsdk:"last_checked"`
+	Error       types.String `tfsdk:"error"`
+}
+
+func (d *BackendHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_health"
+}
+
+func (d *BackendHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "On-demand health probe of backend. Backend is looked up on gateway by name, " +
+			"then `/v1/info` of its `proxy_to` is requested from where terraform runs. Gateway has no probe endpoint, " +
+			"so probe goes directly to backend, not through gateway: it shows whether terraform host reaches backend, " +
+			"not whether gateway does, and it is not retried or counted by gateway circuit breaker. " +
+			"Gateway credentials and provider `headers` are not sent to backend. " +
+			"Failed probe is not an error, it gives `healthy = false` with `error` set",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of backend",
+				Required:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Backend responded and is not starting",
+				Computed:            true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				MarkdownDescription: "Probe duration in milliseconds",
+				Computed:            true,
+			},
+			"last_checked": schema.StringAttribute{
+				MarkdownDescription: "Probe time in RFC3339 format",
+				Computed:            true,
+			},
+			"error": schema.StringAttribute{
+				MarkdownDescription: "Why backend is unhealthy. Null if it is healthy",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BackendHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendHealthDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	health, err := d.client.ProbeBackend(ctx, data.Name.ValueString())
+	if errors.Is(err, trinogatewayclient.ErrBackendNotFound) {
+		resp.Diagnostics.AddError("Backend not found", fmt.Sprintf("Backend %q not found", data.Name.ValueString()))
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to probe backend", err)
+		return
+	}
+	data.Healthy = types.BoolValue(health.Healthy)
+	data.LatencyMs = types.Int64Value(health.Latency.Milliseconds())
+	data.LastChecked = types.StringValue(health.CheckedAt.UTC().Format(time.RFC3339))
+	data.Error = types.StringNull()
+	if health.Error != "" {
+		data.Error = types.StringValue(health.Error)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendHistoryDataSource{}
+
+func NewBackendHistoryDataSource() datasource.DataSource {
+	return &BackendHistoryDataSource{}
+}
+
+// BackendHistoryDataSource defines the data source implementation.
+type BackendHistoryDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendHistoryDataSourceModel describes the data source data model.
+type BackendHistoryDataSourceModel struct {
+	Name    types.String                `tfsdk:"name"`
+	History []BackendHistoryRecordModel `tfsdk:"history"`
+}
+
+type BackendHistoryRecordModel struct {
+	Time         types.String `tfsdk:"time"`
+	Active       types.Bool   `tfsdk:"active"`
+	ProxyTo      types.String `tfsdk:"proxy_to"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+	ExternalUrl  types.String `tfsdk:"external_url"`
+}
+
+func (d *BackendHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_history"
+}
+
+func (d *BackendHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Past states of backend recorded by gateway, for auditing activation changes. " +
+			"Upstream gateway keeps no backend history, `history` is empty for it and other gateways without history endpoint",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of backend",
+				Required:            true,
+			},
+			"history": schema.ListNestedAttribute{
+				MarkdownDescription: "Backend states ordered from oldest",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"time": schema.StringAttribute{
+							MarkdownDescription: "When backend got this state, in RFC3339 format",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Backend activation",
+							Computed:            true,
+						},
+						"proxy_to": schema.StringAttribute{
+							MarkdownDescription: "Backend url",
+							Computed:            true,
+						},
+						"routing_group": schema.StringAttribute{
+							MarkdownDescription: "Routing group name",
+							Computed:            true,
+						},
+						"external_url": schema.StringAttribute{
+							MarkdownDescription: "External backend url",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BackendHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendHistoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	history, err := d.client.GetBackendHistory(ctx, data.Name.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get backend history", err)
+		return
+	}
+	data.History = []BackendHistoryRecordModel{}
+	for _, record := range history {
+		data.History = append(data.History, BackendHistoryRecordModel{
+			Time:         types.StringValue(record.Time.UTC().Format(time.RFC3339)),
+			Active:       types.BoolValue(record.Active),
+			ProxyTo:      types.StringValue(record.ProxyTo),
+			RoutingGroup: types.StringValue(record.RoutingGroup),
+			ExternalUrl:  types.StringValue(record.ExternalUrl),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
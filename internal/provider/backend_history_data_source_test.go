@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBackendHistoryDataSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		history   string
+		wantTimes []string
+	}{
+		{
+			name: "utc from oldest",
+			history: `[
+	{"time":"2026-03-02T12:00:00+02:00","active":true,"proxyTo":"http://trino-1:8081","routingGroup":"etl"},
+	{"time":"2026-03-01T10:00:00Z","active":false,"proxyTo":"http://trino-1:8080","routingGroup":"adhoc"}
+]`,
+			wantTimes: []string{"2026-03-01T10:00:00Z", "2026-03-02T10:00:00Z"},
+		},
+		{name: "no history", history: `[]`, wantTimes: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := readDataSource(t, NewBackendHistoryDataSource(), &TrinoGatewayProviderData{Client: backendsGateway(t, tt.history)}, map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "trino-1"),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var data BackendHistoryDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.History == nil {
+				t.Fatal("got null history, want list")
+			}
+			if len(data.History) != len(tt.wantTimes) {
+				t.Fatalf("got %d records, want %d", len(data.History), len(tt.wantTimes))
+			}
+			for i, record := range data.History {
+				if record.Time.ValueString() != tt.wantTimes[i] {
+					t.Fatalf("got time %q at %d, want %q", record.Time.ValueString(), i, tt.wantTimes[i])
+				}
+			}
+			if len(data.History) > 0 && (data.History[0].Active.ValueBool() || data.History[0].RoutingGroup.ValueString() != "adhoc") {
+				t.Fatalf("unexpected first record: %+v", data.History[0])
+			}
+		})
+	}
+}
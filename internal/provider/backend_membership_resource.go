@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackendMembershipResource{}
+var _ resource.ResourceWithImportState = &BackendMembershipResource{}
+
+func NewBackendMembershipResource() resource.Resource {
+	return &BackendMembershipResource{}
+}
+
+// BackendMembershipResource manages only routing group of existing backend.
+type BackendMembershipResource struct {
+	client  trinogatewayclient.TrinoGatewayClient
+	journal *trinogatewayclient.Journal
+
+	lowercaseRoutingGroups  bool
+	preventLastActiveDelete bool
+}
+
+// BackendMembershipResourceModel describes the resource data model.
+type BackendMembershipResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+}
+
+func (r *BackendMembershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_membership"
+}
+
+func (r *BackendMembershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Routing group assignment of existing backend. Other backend fields are left untouched. " +
+			"Destroying the resource leaves backend in its current routing group",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Internal id for terraform provider",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of existing backend",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *BackendMembershipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.journal = providerData.Journal
+	r.lowercaseRoutingGroups = providerData.LowercaseRoutingGroups
+	r.preventLastActiveDelete = providerData.PreventLastActiveDelete
+}
+
+func (r *BackendMembershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data BackendMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventLastActiveDelete {
+		resp.Diagnostics.Append(r.checkNotLastActiveMoved(ctx, data.Name.ValueString(), data.RoutingGroup.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if err := r.assignRoutingGroup(ctx, data.Name.ValueString(), data.RoutingGroup.ValueString()); err != nil {
+		addMutationError(&resp.Diagnostics, r.journal, "Unable to assign routing group", err)
+		return
+	}
+
+	data.Id = types.StringValue(data.Name.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendMembershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackendMembershipResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backend, err := r.client.GetBackend(ctx, data.Name.ValueString())
+	if errors.Is(err, trinogatewayclient.ErrBackendNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get backend", err)
+		return
+	}
+
+	data.RoutingGroup = preserveRoutingGroup(r.lowercaseRoutingGroups, data.RoutingGroup, backend.RoutingGroup)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendMembershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data BackendMembershipResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventLastActiveDelete {
+		resp.Diagnostics.Append(r.checkNotLastActiveMoved(ctx, data.Name.ValueString(), data.RoutingGroup.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if err := r.assignRoutingGroup(ctx, data.Name.ValueString(), data.RoutingGroup.ValueString()); err != nil {
+		addMutationError(&resp.Diagnostics, r.journal, "Unable to assign routing group", err)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendMembershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Backend is managed elsewhere, nothing to do on gateway
+}
+
+func (r *BackendMembershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// assignRoutingGroup does read-merge-write to keep other backend fields untouched.
+// Write is conditional on backend version, concurrent change of backend is merged again.
+func (r *BackendMembershipResource) assignRoutingGroup(ctx context.Context, name string, routingGroup string) error {
+	routingGroup = normalizeRoutingGroup(r.lowercaseRoutingGroups, routingGroup)
+	for attempt := 0; ; attempt++ {
+		backend, err := r.client.GetBackend(ctx, name)
+		if err != nil {
+			return err
+		}
+		if backend.RoutingGroup == routingGroup {
+			return nil
+		}
+		expectedVersion := trinogatewayclient.BackendVersion(backend)
+		moved := *backend
+		moved.RoutingGroup = routingGroup
+		err = r.client.UpdateBackendIfMatch(ctx, &moved, expectedVersion)
+		var conflictErr *trinogatewayclient.ConflictError
+		if errors.As(err, &conflictErr) && attempt < maxUpdateConflictRetries {
+			tflog.Warn(ctx, "backend was changed concurrently, merge routing group again", map[string]interface{}{"name": name})
+			continue
+		}
+		return err
+	}
+}
+
+// checkNotLastActiveMoved refuses moving the last active backend out of its routing group.
+func (r *BackendMembershipResource) checkNotLastActiveMoved(ctx context.Context, name string, routingGroup string) diag.Diagnostics {
+	return checkNotLastActiveMoved(ctx, r.client, name, normalizeRoutingGroup(r.lowercaseRoutingGroups, routingGroup))
+}
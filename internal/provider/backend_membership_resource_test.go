@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// membershipClient serves backend from gateway and changes it concurrently before first conditional writes.
+type membershipClient struct {
+	trinogatewayclient.TrinoGatewayClient
+
+	backend *trinogatewayclient.Backend
+	// concurrentChanges are applied one per conditional write, before it checks version
+	concurrentChanges []func(backend *trinogatewayclient.Backend)
+	writes            int
+}
+
+func (c *membershipClient) GetBackend(ctx context.Context, name string) (*trinogatewayclient.Backend, error) {
+	backend := *c.backend
+	return &backend, nil
+}
+
+func (c *membershipClient) UpdateBackendIfMatch(ctx context.Context, backend *trinogatewayclient.Backend, expectedVersion string) error {
+	c.writes++
+	if len(c.concurrentChanges) > 0 {
+		c.concurrentChanges[0](c.backend)
+		c.concurrentChanges = c.concurrentChanges[1:]
+	}
+	if currentVersion := trinogatewayclient.BackendVersion(c.backend); currentVersion != expectedVersion {
+		return &trinogatewayclient.ConflictError{Name: backend.Name, CurrentVersion: currentVersion}
+	}
+	updated := *backend
+	c.backend = &updated
+	return nil
+}
+
+func TestAssignRoutingGroup(t *testing.T) {
+	deactivate := func(backend *trinogatewayclient.Backend) { backend.Active = false }
+	tests := []struct {
+		name              string
+		routingGroup      string
+		concurrentChanges []func(backend *trinogatewayclient.Backend)
+		wantWrites        int
+		wantActive        bool
+		wantErr           bool
+	}{
+		{name: "move", routingGroup: "etl", wantWrites: 1, wantActive: true},
+		{name: "already in routing group", routingGroup: "adhoc", wantWrites: 0, wantActive: true},
+		{
+			name:              "concurrent change is kept",
+			routingGroup:      "etl",
+			concurrentChanges: []func(backend *trinogatewayclient.Backend){deactivate},
+			wantWrites:        2,
+			wantActive:        false,
+		},
+		{
+			name:         "conflicts exhaust retries",
+			routingGroup: "etl",
+			concurrentChanges: []func(backend *trinogatewayclient.Backend){
+				deactivate,
+				func(backend *trinogatewayclient.Backend) { backend.Active = true },
+				deactivate,
+				func(backend *trinogatewayclient.Backend) { backend.Active = true },
+			},
+			wantWrites: maxUpdateConflictRetries + 1,
+			wantActive: true,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &membershipClient{
+				backend:           &trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1", RoutingGroup: "adhoc", Active: true},
+				concurrentChanges: tt.concurrentChanges,
+			}
+			r := &BackendMembershipResource{client: client}
+			err := r.assignRoutingGroup(context.Background(), "trino-1", tt.routingGroup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if client.writes != tt.wantWrites {
+				t.Fatalf("got %d writes, want %d", client.writes, tt.wantWrites)
+			}
+			if client.backend.Active != tt.wantActive {
+				t.Fatalf("backend active is %v, want %v", client.backend.Active, tt.wantActive)
+			}
+			if !tt.wantErr && client.backend.RoutingGroup != tt.routingGroup {
+				t.Fatalf("backend routing group is %q, want %q", client.backend.RoutingGroup, tt.routingGroup)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+const (
+	renameHealthyTimeout      = time.Minute
+	renameHealthyPollInterval = time.Second
+)
+
+// requiresReplaceUnlessSafeRename replaces backend on name change, unless safe_rename is set.
+func requiresReplaceUnlessSafeRename() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var safeRename types.Bool
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("safe_rename"), &safeRename)...)
+			resp.RequiresReplace = !safeRename.ValueBool()
+		},
+		"Backend is replaced on name change, unless safe_rename is set.",
+		"Backend is replaced on name change, unless `safe_rename` is set.",
+	)
+}
+
+// renameBackend registers backend under new name, optionally waits until it is healthy and deletes old name,
+// so routing group never loses the backend. Failure after new name is registered rolls it back.
+func renameBackend(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, journal *trinogatewayclient.Journal, oldName string, backend *trinogatewayclient.Backend, waitHealthy bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err := client.AddOrUpdateBackend(ctx, backend); err != nil {
+		addMutationError(&diags, journal, "Unable to register renamed backend", err)
+		return diags
+	}
+	var failure diag.Diagnostics
+	if waitHealthy && backend.Active {
+		failure = waitBackendHealthy(ctx, client, backend.Name)
+	}
+	if !failure.HasError() {
+		if err := client.DeleteBackend(ctx, oldName); err != nil {
+			addMutationError(&failure, journal, fmt.Sprintf("Unable to delete backend %q after registering it as %q", oldName, backend.Name), err)
+		}
+	}
+	if !failure.HasError() {
+		return diags
+	}
+	diags.Append(failure...)
+	tflog.Warn(ctx, "rename failed, rolling back", map[string]interface{}{"old_name": oldName, "new_name": backend.Name})
+	if err := client.DeleteBackend(ctx, backend.Name); err != nil {
+		addMutationError(&diags, journal, fmt.Sprintf("Unable to roll back rename, backend is registered both as %q and %q", oldName, backend.Name), err)
+		return diags
+	}
+	diags.AddError("Backend rename rolled back", fmt.Sprintf("Backend %q was left as is, %q was deleted", oldName, backend.Name))
+	return diags
+}
+
+// waitBackendHealthy polls backend health probe until it succeeds or renameHealthyTimeout passes.
+func waitBackendHealthy(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ctx, cancel := context.WithTimeout(ctx, renameHealthyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(renameHealthyPollInterval)
+	defer ticker.Stop()
+	lastError := ""
+	for {
+		health, err := client.ProbeBackend(ctx, name)
+		if err != nil && ctx.Err() == nil {
+			addClientError(&diags, "Unable to probe renamed backend", err)
+			return diags
+		}
+		if health != nil {
+			if health.Healthy {
+				return diags
+			}
+			lastError = health.Error
+		}
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Renamed backend is not healthy",
+				fmt.Sprintf("Backend %q is not healthy %s after registration, last probe error: %s", name, renameHealthyTimeout, lastError),
+			)
+			return diags
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// renameClient records mutations of rename, other client methods are not expected to be called.
+type renameClient struct {
+	trinogatewayclient.TrinoGatewayClient
+
+	calls     []string
+	deleteErr map[string]error
+	probeErr  error
+}
+
+func (c *renameClient) AddOrUpdateBackend(ctx context.Context, backend *trinogatewayclient.Backend) error {
+	c.calls = append(c.calls, "add "+backend.Name)
+	return nil
+}
+
+func (c *renameClient) DeleteBackend(ctx context.Context, name string) error {
+	c.calls = append(c.calls, "delete "+name)
+	return c.deleteErr[name]
+}
+
+func (c *renameClient) ProbeBackend(ctx context.Context, name string) (*trinogatewayclient.BackendHealth, error) {
+	c.calls = append(c.calls, "probe "+name)
+	if c.probeErr != nil {
+		return nil, c.probeErr
+	}
+	return &trinogatewayclient.BackendHealth{Healthy: true}, nil
+}
+
+func TestRenameBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      *renameClient
+		active      bool
+		waitHealthy bool
+		wantCalls   []string
+		wantErr     bool
+	}{
+		{
+			name:      "without wait",
+			client:    &renameClient{},
+			active:    true,
+			wantCalls: []string{"add new", "delete old"},
+		},
+		{
+			name:        "wait healthy",
+			client:      &renameClient{},
+			active:      true,
+			waitHealthy: true,
+			wantCalls:   []string{"add new", "probe new", "delete old"},
+		},
+		{
+			name:        "inactive backend is not waited",
+			client:      &renameClient{},
+			waitHealthy: true,
+			wantCalls:   []string{"add new", "delete old"},
+		},
+		{
+			name:        "probe failure rolls back",
+			client:      &renameClient{probeErr: errors.New("gateway is down")},
+			active:      true,
+			waitHealthy: true,
+			wantCalls:   []string{"add new", "probe new", "delete new"},
+			wantErr:     true,
+		},
+		{
+			name:      "old name delete failure rolls back",
+			client:    &renameClient{deleteErr: map[string]error{"old": errors.New("boom")}},
+			active:    true,
+			wantCalls: []string{"add new", "delete old", "delete new"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &trinogatewayclient.Backend{Name: "new", Active: tt.active}
+			diags := renameBackend(context.Background(), tt.client, nil, "old", backend, tt.waitHealthy)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got errors %v, want error: %v", diags, tt.wantErr)
+			}
+			if !reflect.DeepEqual(tt.client.calls, tt.wantCalls) {
+				t.Fatalf("got calls %v, want %v", tt.client.calls, tt.wantCalls)
+			}
+		})
+	}
+}
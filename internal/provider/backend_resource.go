@@ -5,19 +5,49 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
 )
 
+const (
+	// privateKeyBackendMissing marks backend deleted outside of terraform
+	privateKeyBackendMissing = "backend_missing"
+	// privateKeyImportedBackend is backend fetched by import, checked by first read after it
+	privateKeyImportedBackend = "imported_backend"
+	// privateKeyBackendVersion is BackendVersion of backend as provider last saw it on gateway
+	privateKeyBackendVersion = "backend_version"
+
+	maxUpdateConflictRetries = 3
+
+	maxListedImportIds = 20
+
+	confirmDeleteTimeout      = time.Minute
+	confirmDeletePollInterval = time.Second
+)
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BackendResource{}
 var _ resource.ResourceWithImportState = &BackendResource{}
+var _ resource.ResourceWithModifyPlan = &BackendResource{}
+var _ resource.ResourceWithValidateConfig = &BackendResource{}
 
 func NewBackendResource() resource.Resource {
 	return &BackendResource{}
@@ -25,17 +55,37 @@ func NewBackendResource() resource.Resource {
 
 // BackendResource defines the resource implementation.
 type BackendResource struct {
-	client trinogatewayclient.TrinoGatewayClient
+	client       trinogatewayclient.TrinoGatewayClient
+	providerData *TrinoGatewayProviderData
 }
 
 // BackendResourceModel describes the resource data model.
 type BackendResourceModel struct {
-	Id           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	ProxyTo      types.String `tfsdk:"proxy_to"`
-	Active       types.Bool   `tfsdk:"active"`
-	RoutingGroup types.String `tfsdk:"routing_group"`
-	ExternalUrl  types.String `tfsdk:"external_url"`
+	Id                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	ProxyTo               types.String `tfsdk:"proxy_to"`
+	Active                types.Bool   `tfsdk:"active"`
+	RoutingGroup          types.String `tfsdk:"routing_group"`
+	ExternalUrl           types.String `tfsdk:"external_url"`
+	Weight                types.Int64  `tfsdk:"weight"`
+	ProxyUrl              types.String `tfsdk:"proxy_url"`
+	SkipDnsCheck          types.Bool   `tfsdk:"skip_dns_check"`
+	SafeRename            types.Bool   `tfsdk:"safe_rename"`
+	SafeRenameWaitHealthy types.Bool   `tfsdk:"safe_rename_wait_healthy"`
+
+	Auth *BackendAuthModel `tfsdk:"auth"`
+
+	IsDefaultRoutingGroup types.Bool  `tfsdk:"is_default_routing_group"`
+	InFlightQueries       types.Int64 `tfsdk:"in_flight_queries"`
+	// RoutingGroupMemberCount includes backend itself
+	RoutingGroupMemberCount types.Int64 `tfsdk:"routing_group_member_count"`
+}
+
+// BackendAuthModel overrides provider credentials for backend operations.
+type BackendAuthModel struct {
+	Login    types.String `tfsdk:"login"`
+	Password types.String `tfsdk:"password"`
+	ApiKey   types.String `tfsdk:"api_key"`
 }
 
 func (r *BackendResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,29 +108,105 @@ func (r *BackendResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Name of backend",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceUnlessSafeRename(),
 				},
 			},
 			"proxy_to": schema.StringAttribute{
-				MarkdownDescription: "Backend url",
+				MarkdownDescription: "Backend url. Active backend must have http or https url with host",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					normalizeUrlTrailingSlash(),
+				},
 			},
 			"active": schema.BoolAttribute{
 				MarkdownDescription: "Backend activation",
 				Required:            true,
 			},
 			"routing_group": schema.StringAttribute{
-				MarkdownDescription: "Routing group name",
-				Required:            true,
-			},
-			"external_url": schema.StringAttribute{
-				MarkdownDescription: "If the backend URL is different from the proxyTo URL (for example if they are internal vs. external hostnames)",
+				MarkdownDescription: "Routing group name, gateway backend belongs to exactly one routing group. Defaults to provider `default_routing_group`",
 				Optional:            true,
 				Computed:            true,
+			},
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "If the backend URL is different from the proxyTo URL (for example if they are internal vs. external hostnames). " +
+					"If not set, it is filled according to provider `external_url_default`",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					normalizeUrlTrailingSlash(),
+				},
+			},
+			"weight": schema.Int64Attribute{
+				MarkdownDescription: "Weight for routing inside routing group. Not part of upstream Trino Gateway backend entity, for gateways with weighted routing. Sent only when set, explicit 0 is sent",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy for gateway requests about this backend, overrides provider `proxy_url`",
+				Optional:            true,
+			},
+			"auth": schema.SingleNestedAttribute{
+				MarkdownDescription: "Gateway credentials for operations on this backend, override provider ones. " +
+					"For backends managed by another gateway admin account. Api key is sent in provider `api_key_header`. " +
+					"Unlike provider credentials, these are stored in state, marked sensitive",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"login": schema.StringAttribute{
+						MarkdownDescription: "Login of basic auth",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("api_key")),
+						},
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "Password of basic auth",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("login")),
+						},
+					},
+					"api_key": schema.StringAttribute{
+						MarkdownDescription: "Api key",
+						Optional:            true,
+						Sensitive:           true,
+					},
 				},
 			},
+			"safe_rename": schema.BoolAttribute{
+				MarkdownDescription: "Rename backend without downtime instead of replacing it: register it under new name, " +
+					"then delete old name, so routing group always has the backend. " +
+					"Failure after new name is registered deletes it again. " +
+					"Gateway routing rules select routing groups, not backend names, so nothing has to be repointed",
+				Optional: true,
+			},
+			"safe_rename_wait_healthy": schema.BoolAttribute{
+				MarkdownDescription: "With `safe_rename` wait up to 1m until active renamed backend is healthy before deleting old name. " +
+					"Health is probed from where terraform runs directly to `proxy_to`, like `trinogateway_backend_health` does, " +
+					"so backend must be reachable from terraform host",
+				Optional: true,
+			},
+			"skip_dns_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip provider `check_proxy_to_dns` for this backend",
+				Optional:            true,
+			},
+			"is_default_routing_group": schema.BoolAttribute{
+				MarkdownDescription: "Whether `routing_group` is the gateway default routing group. Null if gateway does not report it",
+				Computed:            true,
+			},
+			"in_flight_queries": schema.Int64Attribute{
+				MarkdownDescription: "Number of running and queued queries on backend, useful to watch draining after deactivation. Refreshed on every read. " +
+					"Null unless provider `read_in_flight_queries` is set or if gateway does not report backend state",
+				Computed: true,
+			},
+			"routing_group_member_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of backends in `routing_group`, including this one and inactive ones. Refreshed on every read",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -91,20 +217,24 @@ func (r *BackendResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(trinogatewayclient.TrinoGatewayClient)
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected trinogatewayclient.TrinoGatewayClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
 }
 
 func (r *BackendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
 	var data BackendResourceModel
 
 	// Read Terraform plan data into the model
@@ -113,27 +243,48 @@ func (r *BackendResource) Create(ctx context.Context, req resource.CreateRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if r.providerData.UseGatewayDefaults {
+		if err := r.applyGatewayDefaults(ctx, &data); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to get gateway backend defaults", err)
+			return
+		}
+	}
+	if data.RoutingGroup.IsNull() || data.RoutingGroup.IsUnknown() {
+		if r.providerData.DefaultRoutingGroup == "" {
+			resp.Diagnostics.AddError(
+				"Routing group is not set",
+				"routing_group is not set and provider default_routing_group is not configured",
+			)
+			return
+		}
+		data.RoutingGroup = types.StringValue(r.providerData.DefaultRoutingGroup)
+	}
 	backend := &trinogatewayclient.Backend{
 		Name:         data.Name.ValueString(),
 		ProxyTo:      data.ProxyTo.ValueString(),
-		RoutingGroup: data.RoutingGroup.ValueString(),
+		RoutingGroup: normalizeRoutingGroup(r.providerData.LowercaseRoutingGroups, data.RoutingGroup.ValueString()),
 		Active:       data.Active.ValueBool(),
+		Weight:       tfToWeight(data.Weight),
 	}
-	if data.ExternalUrl.IsNull() || data.ExternalUrl.IsUnknown() {
-		data.ExternalUrl = types.StringValue(data.ProxyTo.ValueString())
-	}
+	r.defaultExternalUrl(&data)
 	backend.ExternalUrl = data.ExternalUrl.ValueString()
 
-	err := r.client.AddOrUpdateBackend(ctx, backend)
+	client, diags := r.backendClient(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := client.AddOrUpdateBackend(ctx, backend)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to add backend, got error: %s", err),
-		)
+		addMutationError(&resp.Diagnostics, r.providerData.Journal, "Unable to add backend", err)
 		return
 	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyBackendVersion, []byte(strconv.Quote(trinogatewayclient.BackendVersion(backend))))...)
 
 	data.Id = types.StringValue(data.Name.ValueString())
+	data.IsDefaultRoutingGroup = r.isDefaultRoutingGroup(ctx, client, data.RoutingGroup.ValueString())
+	data.InFlightQueries = r.inFlightQueries(ctx, client, data.Name.ValueString())
+	data.RoutingGroupMemberCount = r.routingGroupMemberCount(ctx, client, data.RoutingGroup.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -147,30 +298,152 @@ func (r *BackendResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	backends, err := r.client.GetAllBackends(ctx)
+	client, diags := r.backendClient(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	backends, err := r.providerData.BackendsSnapshot.Get(ctx, client)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list backends, got error: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
 		return
 	}
 
-	var foundBackend *trinogatewayclient.Backend
-	for _, backend := range backends {
-		if backend.Name == data.Name.ValueString() {
-			foundBackend = backend
-		}
-	}
+	foundBackend, diags := findBackend(backends, data.Name.ValueString())
+	resp.Diagnostics.Append(diags...)
 
 	if foundBackend == nil {
+		if r.providerData.RecreateMissing {
+			// keep resource in state, ModifyPlan will plan its replacement
+			tflog.Warn(ctx, "backend is missing on gateway, it will be recreated", map[string]interface{}{"name": data.Name.ValueString()})
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyBackendMissing, []byte("true"))...)
+			return
+		}
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyBackendMissing, nil)...)
+	resp.Diagnostics.Append(r.checkImportConsistency(ctx, req, resp, foundBackend)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyBackendVersion, []byte(strconv.Quote(trinogatewayclient.BackendVersion(foundBackend))))...)
 
+	priorProxyTo, priorExternalUrl, priorRoutingGroup := data.ProxyTo, data.ExternalUrl, data.RoutingGroup
 	backendDomainToTfModel(foundBackend, &data)
+	data.RoutingGroup = preserveRoutingGroup(r.providerData.LowercaseRoutingGroups, priorRoutingGroup, foundBackend.RoutingGroup)
+	// dont produce diff if gateway normalized trailing slash
+	data.ProxyTo = preserveEquivalentUrl(priorProxyTo, foundBackend.ProxyTo)
+	data.ExternalUrl = r.readExternalUrl(priorExternalUrl, foundBackend)
+	data.IsDefaultRoutingGroup = r.isDefaultRoutingGroup(ctx, client, data.RoutingGroup.ValueString())
+	data.InFlightQueries = r.inFlightQueries(ctx, client, data.Name.ValueString())
+	data.RoutingGroupMemberCount = r.routingGroupMemberCount(ctx, client, data.RoutingGroup.ValueString())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ValidateConfig rejects active backend with proxy_to gateway cant route to. Inactive backends may keep placeholder url.
+func (r *BackendResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var active types.Bool
+	var proxyTo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("active"), &active)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("proxy_to"), &proxyTo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !active.ValueBool() || proxyTo.IsUnknown() {
+		return
+	}
+	if err := trinogatewayclient.ValidateBackendUrl(proxyTo.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_to"),
+			"Invalid proxy_to of active backend",
+			fmt.Sprintf("Active backend must have http or https proxy_to with host, gateway would fail to route queries to it: %s. "+
+				"Fix proxy_to or set active = false", err),
+		)
+	}
+}
+
+func (r *BackendResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	// Provider is not configured yet, everything will be resolved during apply
+	if r.providerData == nil {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var planName, stateName types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+		// safe rename changes id in place
+		if !planName.Equal(stateName) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+
+		missing, diags := req.Private.GetKey(ctx, privateKeyBackendMissing)
+		resp.Diagnostics.Append(diags...)
+		if string(missing) == "true" {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("id"))
+		}
+	}
+
+	if r.providerData.DefaultRoutingGroup != "" {
+		var routingGroup types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("routing_group"), &routingGroup)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if routingGroup.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("routing_group"), r.providerData.DefaultRoutingGroup)...)
+		}
+	}
+
+	resp.Diagnostics.Append(warnDeprecatedAttributes(ctx, req.Config, deprecatedBackendAttributes, func() (string, error) {
+		return r.providerData.gatewayVersion.get(ctx, r.client)
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.providerData.Resolver != nil {
+		var proxyTo types.String
+		var skipDnsCheck types.Bool
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("proxy_to"), &proxyTo)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("skip_dns_check"), &skipDnsCheck)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !skipDnsCheck.ValueBool() {
+			resp.Diagnostics.Append(checkUrlHostResolves(ctx, r.providerData.Resolver, path.Root("proxy_to"), proxyTo)...)
+		}
+	}
+
+	if r.providerData.WarnUrlSchemeMismatch {
+		var proxyTo, externalUrl types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("proxy_to"), &proxyTo)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("external_url"), &externalUrl)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !urlSchemesMatch(proxyTo, externalUrl) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("external_url"),
+				"Url scheme mismatch",
+				fmt.Sprintf(
+					"external_url %q and proxy_to %q use different schemes, which is usually unintentional",
+					externalUrl.ValueString(),
+					proxyTo.ValueString(),
+				),
+			)
+		}
+	}
+}
+
 func (r *BackendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
 	var data BackendResourceModel
 
 	// Read Terraform plan data into the model
@@ -180,30 +453,86 @@ func (r *BackendResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	if data.RoutingGroup.IsNull() || data.RoutingGroup.IsUnknown() {
+		if r.providerData.DefaultRoutingGroup == "" {
+			resp.Diagnostics.AddError(
+				"Routing group is not set",
+				"routing_group is not set and provider default_routing_group is not configured",
+			)
+			return
+		}
+		data.RoutingGroup = types.StringValue(r.providerData.DefaultRoutingGroup)
+	}
 	backend := &trinogatewayclient.Backend{
 		Name:         data.Name.ValueString(),
 		ProxyTo:      data.ProxyTo.ValueString(),
-		RoutingGroup: data.RoutingGroup.ValueString(),
+		RoutingGroup: normalizeRoutingGroup(r.providerData.LowercaseRoutingGroups, data.RoutingGroup.ValueString()),
 		Active:       data.Active.ValueBool(),
+		Weight:       tfToWeight(data.Weight),
 	}
-	if data.ExternalUrl.IsNull() || data.ExternalUrl.IsUnknown() {
-		data.ExternalUrl = types.StringValue(data.ProxyTo.ValueString())
-	}
+	r.defaultExternalUrl(&data)
 	backend.ExternalUrl = data.ExternalUrl.ValueString()
-	err := r.client.AddOrUpdateBackend(ctx, backend)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to update backend, got error: %s", err),
-		)
+	var priorName, priorRoutingGroup types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("routing_group"), &priorRoutingGroup)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	client, diags := r.backendClient(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.providerData.PreventLastActiveDelete && !backend.Active {
+		resp.Diagnostics.Append(r.checkNotLastActive(ctx, client, priorName.ValueString(), "deactivate")...)
+	} else if r.providerData.PreventLastActiveDelete && normalizeRoutingGroup(r.providerData.LowercaseRoutingGroups, priorRoutingGroup.ValueString()) != backend.RoutingGroup {
+		resp.Diagnostics.Append(checkNotLastActiveMoved(ctx, client, priorName.ValueString(), backend.RoutingGroup)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	current, err := client.GetBackend(ctx, backend.Name)
+	if err != nil && !errors.Is(err, trinogatewayclient.ErrBackendNotFound) {
+		addClientError(&resp.Diagnostics, "Unable to get backend", err)
+		return
+	}
+	if priorName.ValueString() != backend.Name {
+		if current != nil {
+			resp.Diagnostics.AddError("Unable to rename backend", fmt.Sprintf("Backend %q already exists in gateway", backend.Name))
+			return
+		}
+		resp.Diagnostics.Append(renameBackend(ctx, client, r.providerData.Journal, priorName.ValueString(), backend, data.SafeRenameWaitHealthy.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		data.Id = types.StringValue(backend.Name)
+	} else if current.Equal(backend) {
+		tflog.Debug(ctx, "backend on gateway already matches plan, skip update", map[string]interface{}{"name": backend.Name})
+	} else if r.providerData.UseGracefulDeactivate && onlyDeactivated(current, backend) {
+		if err := client.DeactivateBackend(ctx, backend.Name); err != nil {
+			addMutationError(&resp.Diagnostics, r.providerData.Journal, "Unable to deactivate backend", err)
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(r.updateBackend(ctx, req, client, backend)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyBackendVersion, []byte(strconv.Quote(trinogatewayclient.BackendVersion(backend))))...)
+
+	data.IsDefaultRoutingGroup = r.isDefaultRoutingGroup(ctx, client, data.RoutingGroup.ValueString())
+	data.InFlightQueries = r.inFlightQueries(ctx, client, data.Name.ValueString())
+	data.RoutingGroupMemberCount = r.routingGroupMemberCount(ctx, client, data.RoutingGroup.ValueString())
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *BackendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
 	var data BackendResourceModel
 
 	// Read Terraform prior state data into the model
@@ -213,34 +542,434 @@ func (r *BackendResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	if err := r.client.DeleteBackend(ctx, data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete backend, got error: %s", err))
+	missing, diags := req.Private.GetKey(ctx, privateKeyBackendMissing)
+	resp.Diagnostics.Append(diags...)
+	if string(missing) == "true" {
+		// Already deleted outside of terraform
 		return
 	}
+
+	client, diags := r.backendClient(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.providerData.PreventLastActiveDelete {
+		resp.Diagnostics.Append(r.checkNotLastActive(ctx, client, data.Name.ValueString(), "delete")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if r.providerData.OrphanCheck != "" {
+		resp.Diagnostics.Append(r.checkNotOrphaningDefaultRoutingGroup(ctx, client, data.Name.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if err := client.DeleteBackend(ctx, data.Name.ValueString()); err != nil {
+		addMutationError(&resp.Diagnostics, r.providerData.Journal, "Unable to delete backend", err)
+		return
+	}
+	if r.providerData.ConfirmDelete {
+		resp.Diagnostics.Append(waitBackendDeleted(ctx, client, data.Name.ValueString())...)
+	}
 }
 
 func (r *BackendResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	backendName := req.ID
+	// imported resource has no config, so there are no per-backend overrides
+	client := r.client
+	backends, err := client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
 
-	backends, err := r.client.GetAllBackends(ctx)
+	foundBackend, diags := findImportedBackend(backends, req.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var data BackendResourceModel
+	backendDomainToTfModel(foundBackend, &data)
+	data.ExternalUrl = r.readExternalUrl(types.StringNull(), foundBackend)
+	if r.providerData.ImportExternalUrl == importExternalUrlExplicit {
+		// gateway cant tell explicit external_url equal to proxy_to from defaulted one, user decides
+		data.ExternalUrl = preserveEquivalentUrl(types.StringNull(), foundBackend.ExternalUrl)
+	}
+	data.IsDefaultRoutingGroup = r.isDefaultRoutingGroup(ctx, client, data.RoutingGroup.ValueString())
+	data.InFlightQueries = r.inFlightQueries(ctx, client, data.Name.ValueString())
+	data.RoutingGroupMemberCount = r.routingGroupMemberCount(ctx, client, data.RoutingGroup.ValueString())
+
+	imported, err := json.Marshal(foundBackend)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list backends, got error: %s", err))
+		resp.Diagnostics.AddError("Unable to save imported backend", err.Error())
 		return
 	}
+	data.Id = types.StringValue(foundBackend.Name)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateKeyImportedBackend, imported)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findImportedBackend looks import id up as backend name first, so names with slash can be imported,
+// then as "routing_group/name".
+func findImportedBackend(backends []*trinogatewayclient.Backend, id string) (*trinogatewayclient.Backend, diag.Diagnostics) {
+	foundBackend, diags := findBackend(backends, id)
+	if foundBackend != nil {
+		return foundBackend, diags
+	}
+	routingGroup, backendName, found := strings.Cut(id, "/")
+	if found {
+		foundBackend, diags = findBackend(backends, backendName)
+	}
+	if foundBackend == nil {
+		diags.AddError(
+			"Backend not found",
+			fmt.Sprintf("Backend %q not found, available ids: %s", id, availableImportIds(backends)),
+		)
+		return nil, diags
+	}
+	if foundBackend.RoutingGroup != routingGroup {
+		diags.AddError(
+			"Backend routing group mismatch",
+			fmt.Sprintf("Backend %q belongs to routing group %q, not %q", backendName, foundBackend.RoutingGroup, routingGroup),
+		)
+		return nil, diags
+	}
+	return foundBackend, diags
+}
+
+// availableImportIds lists first maxListedImportIds backend names sorted, to hint import id on typo.
+func availableImportIds(backends []*trinogatewayclient.Backend) string {
+	if len(backends) == 0 {
+		return "none, gateway has no backends"
+	}
+	sorted := sortedBackends(backends)
+	names := make([]string, 0, min(len(sorted), maxListedImportIds))
+	for _, backend := range sorted[:min(len(sorted), maxListedImportIds)] {
+		names = append(names, backend.Name)
+	}
+	if len(sorted) > maxListedImportIds {
+		return fmt.Sprintf("%s and %d more", strings.Join(names, ", "), len(sorted)-maxListedImportIds)
+	}
+	return strings.Join(names, ", ")
+}
+
+// checkImportConsistency warns if first read after import sees other backend than import did.
+// It should never happen, but it would point to a bug in import path.
+func (r *BackendResource) checkImportConsistency(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse, actual *trinogatewayclient.Backend) diag.Diagnostics {
+	raw, diags := req.Private.GetKey(ctx, privateKeyImportedBackend)
+	if diags.HasError() || len(raw) == 0 {
+		return diags
+	}
+	diags.Append(resp.Private.SetKey(ctx, privateKeyImportedBackend, nil)...)
+	var imported trinogatewayclient.Backend
+	if err := json.Unmarshal(raw, &imported); err != nil {
+		tflog.Warn(ctx, "cant decode imported backend", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+	if !imported.Equal(actual) {
+		tflog.Warn(ctx, "backend read after import differs from imported one", map[string]interface{}{
+			"name":     actual.Name,
+			"imported": fmt.Sprintf("%+v", imported),
+			"actual":   fmt.Sprintf("%+v", *actual),
+		})
+	}
+	return diags
+}
 
-	var foundBackend *trinogatewayclient.Backend
+// defaultExternalUrl fills unset external_url according to provider external_url_default.
+func (r *BackendResource) defaultExternalUrl(data *BackendResourceModel) {
+	if !data.ExternalUrl.IsNull() && !data.ExternalUrl.IsUnknown() {
+		return
+	}
+	if r.providerData.ExternalUrlDefault == externalUrlDefaultNull {
+		data.ExternalUrl = types.StringNull()
+		return
+	}
+	data.ExternalUrl = types.StringValue(data.ProxyTo.ValueString())
+}
+
+// readExternalUrl converts gateway external url to state value.
+// In null mode unset external_url stays null while gateway keeps it empty or equal to proxy_to.
+func (r *BackendResource) readExternalUrl(prior types.String, backend *trinogatewayclient.Backend) types.String {
+	if r.providerData.ExternalUrlDefault == externalUrlDefaultNull && prior.IsNull() &&
+		(backend.ExternalUrl == "" || urlsEquivalent(backend.ExternalUrl, backend.ProxyTo)) {
+		return types.StringNull()
+	}
+	return preserveEquivalentUrl(prior, backend.ExternalUrl)
+}
+
+// findBackend returns the last backend with given name
+// and warns if gateway lists the name several times, which is gateway data integrity issue.
+func findBackend(backends []*trinogatewayclient.Backend, name string) (*trinogatewayclient.Backend, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var found []*trinogatewayclient.Backend
 	for _, backend := range backends {
-		if backend.Name == backendName {
-			foundBackend = backend
+		if backend.Name == name {
+			found = append(found, backend)
 		}
 	}
-	if foundBackend == nil {
-		resp.Diagnostics.AddError("Backend not found", "Backend not found")
+	if len(found) == 0 {
+		return nil, diags
 	}
-	var data BackendResourceModel
-	backendDomainToTfModel(foundBackend, &data)
+	if len(found) > 1 {
+		entries := make([]string, 0, len(found))
+		for _, backend := range found {
+			entries = append(entries, fmt.Sprintf("%+v", *backend))
+		}
+		diags.AddWarning(
+			"Duplicated backend name",
+			fmt.Sprintf("Gateway lists backend %q %d times, the last one is used: %s", name, len(found), strings.Join(entries, ", ")),
+		)
+	}
+	return found[len(found)-1], diags
+}
 
-	resp.State.Set(ctx, &data)
+// backendClient returns client honoring backend proxy_url and auth overrides.
+func (r *BackendResource) backendClient(data *BackendResourceModel) (trinogatewayclient.TrinoGatewayClient, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rawProxyUrl := ""
+	if !data.ProxyUrl.IsNull() && !data.ProxyUrl.IsUnknown() {
+		rawProxyUrl = data.ProxyUrl.ValueString()
+	}
+	var credentials *credentialsOverride
+	if data.Auth != nil {
+		credentials = &credentialsOverride{apiKey: data.Auth.ApiKey.ValueString()}
+		if !data.Auth.Login.IsNull() {
+			credentials.auth = &trinogatewayclient.Auth{Login: data.Auth.Login.ValueString(), Password: data.Auth.Password.ValueString()}
+		}
+	}
+	if rawProxyUrl == "" && credentials == nil {
+		return r.client, diags
+	}
+	client, err := r.providerData.ClientWithOverrides(rawProxyUrl, credentials)
+	if err != nil {
+		diags.AddAttributeError(path.Root("proxy_url"), "Invalid proxy url", err.Error())
+		return nil, diags
+	}
+	return client, diags
+}
+
+// updateBackend writes backend, with detect_update_conflicts only if it was not changed since last read.
+// With retry_on_conflict concurrent change is logged and overwritten.
+func (r *BackendResource) updateBackend(ctx context.Context, req resource.UpdateRequest, client trinogatewayclient.TrinoGatewayClient, backend *trinogatewayclient.Backend) diag.Diagnostics {
+	var diags diag.Diagnostics
+	rawVersion, privateDiags := req.Private.GetKey(ctx, privateKeyBackendVersion)
+	diags.Append(privateDiags...)
+	expectedVersion, err := strconv.Unquote(string(rawVersion))
+	if !r.providerData.DetectUpdateConflicts || err != nil {
+		if err := client.AddOrUpdateBackend(ctx, backend); err != nil {
+			addMutationError(&diags, r.providerData.Journal, "Unable to update backend", err)
+		}
+		return diags
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := client.UpdateBackendIfMatch(ctx, backend, expectedVersion)
+		var conflictErr *trinogatewayclient.ConflictError
+		if errors.As(err, &conflictErr) && r.providerData.RetryOnConflict && attempt < maxUpdateConflictRetries {
+			tflog.Warn(ctx, "backend was changed concurrently, retry with refreshed version", map[string]interface{}{"name": backend.Name})
+			expectedVersion = conflictErr.CurrentVersion
+			continue
+		}
+		if errors.As(err, &conflictErr) {
+			diags.AddError(
+				"Backend changed concurrently",
+				fmt.Sprintf("Backend %q was changed on gateway after terraform read it. Refresh and plan again, or enable provider `retry_on_conflict` to overwrite", backend.Name),
+			)
+			return diags
+		}
+		if err != nil {
+			addMutationError(&diags, r.providerData.Journal, "Unable to update backend", err)
+		}
+		return diags
+	}
+}
+
+// waitBackendDeleted polls gateway until backend is gone, some gateways delete asynchronously.
+func waitBackendDeleted(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ctx, cancel := context.WithTimeout(ctx, confirmDeleteTimeout)
+	defer cancel()
+	ticker := time.NewTicker(confirmDeletePollInterval)
+	defer ticker.Stop()
+	for {
+		_, err := client.GetBackend(ctx, name)
+		if errors.Is(err, trinogatewayclient.ErrBackendNotFound) {
+			return diags
+		}
+		if err != nil && ctx.Err() == nil {
+			addClientError(&diags, "Unable to confirm backend deletion", err)
+			return diags
+		}
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Backend deletion is not confirmed",
+				fmt.Sprintf("Backend %q is still present on gateway %s after delete request", name, confirmDeleteTimeout),
+			)
+			return diags
+		case <-ticker.C:
+		}
+	}
+}
+
+// onlyDeactivated reports whether desired differs from current only by active=false.
+func onlyDeactivated(current *trinogatewayclient.Backend, desired *trinogatewayclient.Backend) bool {
+	if current == nil || !current.Active || desired.Active {
+		return false
+	}
+	activeCurrent := *desired
+	activeCurrent.Active = true
+	return current.Equal(&activeCurrent)
+}
+
+// checkNotLastActive fails if backend is the last active one in its routing group.
+func (r *BackendResource) checkNotLastActive(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	backends, err := client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list backends", err)
+		return diags
+	}
+	var target *trinogatewayclient.Backend
+	for _, backend := range backends {
+		if backend.Name == name {
+			target = backend
+		}
+	}
+	// nothing to protect: backend is already gone or inactive
+	if target == nil || !target.Active {
+		return diags
+	}
+	for _, backend := range backends {
+		if backend.Name != name && backend.RoutingGroup == target.RoutingGroup && backend.Active {
+			return diags
+		}
+	}
+	addGuardError(
+		&diags,
+		"prevent_last_active_delete",
+		fmt.Sprintf(
+			"Refusing to %s backend %q: it is the last active backend in routing group %q. Activate another backend first",
+			operation, name, target.RoutingGroup,
+		),
+	)
+	return diags
+}
+
+// checkNotOrphaningDefaultRoutingGroup reports deletion of the last backend of gateway default routing group,
+// queries routed to it would have nowhere to go. It is the only routing group reference provider knows about.
+func (r *BackendResource) checkNotOrphaningDefaultRoutingGroup(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defaultRoutingGroup, err := client.GetDefaultRoutingGroup(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to get default routing group", err)
+		return diags
+	}
+	if defaultRoutingGroup == "" {
+		return diags
+	}
+	backends, err := client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list backends", err)
+		return diags
+	}
+	members := groupBackendsByRoutingGroup(backends)[defaultRoutingGroup]
+	if len(members) != 1 || members[0].Name != name {
+		return diags
+	}
+	summary := "Default routing group orphaned"
+	detail := fmt.Sprintf("Backend %q is the last backend of gateway default routing group %q", name, defaultRoutingGroup)
+	if r.providerData.OrphanCheck == orphanCheckError {
+		addGuardError(&diags, "orphan_check", detail+". Register another backend in it or change default routing group first")
+		return diags
+	}
+	diags.AddWarning(summary, detail)
+	return diags
+}
+
+// applyGatewayDefaults fills unset fields, explicit values and provider defaults take precedence.
+func (r *BackendResource) applyGatewayDefaults(ctx context.Context, data *BackendResourceModel) error {
+	defaults, err := r.client.GetBackendDefaults(ctx)
+	if err != nil {
+		return err
+	}
+	if defaults == nil {
+		return nil
+	}
+	if (data.RoutingGroup.IsNull() || data.RoutingGroup.IsUnknown()) &&
+		r.providerData.DefaultRoutingGroup == "" &&
+		defaults.RoutingGroup != "" {
+		data.RoutingGroup = types.StringValue(defaults.RoutingGroup)
+	}
+	if (data.ExternalUrl.IsNull() || data.ExternalUrl.IsUnknown()) && defaults.ExternalUrlTemplate != "" {
+		data.ExternalUrl = types.StringValue(strings.ReplaceAll(defaults.ExternalUrlTemplate, "{name}", data.Name.ValueString()))
+	}
+	return nil
+}
+
+// isDefaultRoutingGroup returns null if the default routing group cant be determined.
+// Default routing group is fetched once per operation and client.
+func (r *BackendResource) isDefaultRoutingGroup(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, routingGroup string) types.Bool {
+	defaultRoutingGroup, err := r.providerData.BackendsSnapshot.DefaultRoutingGroup(ctx, client)
+	if err != nil {
+		tflog.Warn(ctx, "cant get default routing group", map[string]interface{}{"error": err.Error()})
+		return types.BoolNull()
+	}
+	if defaultRoutingGroup == "" {
+		return types.BoolNull()
+	}
+	return types.BoolValue(defaultRoutingGroup == normalizeRoutingGroup(r.providerData.LowercaseRoutingGroups, routingGroup))
+}
+
+// routingGroupMemberCount counts backends of routing group in backends snapshot, it is null if gateway cant be listed.
+func (r *BackendResource) routingGroupMemberCount(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, routingGroup string) types.Int64 {
+	backends, err := r.providerData.BackendsSnapshot.Get(ctx, client)
+	if err != nil {
+		tflog.Warn(ctx, "cant list backends to count routing group members", map[string]interface{}{"routing_group": routingGroup, "error": err.Error()})
+		return types.Int64Null()
+	}
+	routingGroup = normalizeRoutingGroup(r.providerData.LowercaseRoutingGroups, routingGroup)
+	var count int64
+	for _, backend := range backends {
+		if backend.RoutingGroup == routingGroup {
+			count++
+		}
+	}
+	return types.Int64Value(count)
+}
+
+// inFlightQueries is null unless provider read_in_flight_queries is set, gateway reports it per backend only.
+func (r *BackendResource) inFlightQueries(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string) types.Int64 {
+	if !r.providerData.ReadInFlightQueries {
+		return types.Int64Null()
+	}
+	inFlight, err := client.GetBackendInFlightQueries(ctx, name)
+	if err != nil {
+		tflog.Warn(ctx, "cant get backend in-flight queries", map[string]interface{}{"name": name, "error": err.Error()})
+		return types.Int64Null()
+	}
+	return types.Int64PointerValue(inFlight)
+}
+
+// urlSchemesMatch treats unknown, null or unparsable values as matching.
+func urlSchemesMatch(a types.String, b types.String) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return true
+	}
+	parsedA, err := url.Parse(a.ValueString())
+	if err != nil {
+		return true
+	}
+	parsedB, err := url.Parse(b.ValueString())
+	if err != nil {
+		return true
+	}
+	return strings.EqualFold(parsedA.Scheme, parsedB.Scheme)
 }
 
 func backendDomainToTfModel(domainmodel *trinogatewayclient.Backend, tfmodel *BackendResourceModel) {
@@ -249,4 +978,23 @@ func backendDomainToTfModel(domainmodel *trinogatewayclient.Backend, tfmodel *Ba
 	tfmodel.Name = types.StringValue(domainmodel.Name)
 	tfmodel.RoutingGroup = types.StringValue(domainmodel.RoutingGroup)
 	tfmodel.ExternalUrl = types.StringValue(domainmodel.ExternalUrl)
+	tfmodel.Weight = weightToTf(tfmodel.Weight, domainmodel.Weight)
+}
+
+// weightToTf keeps null weight when gateway reports zero weight of backend created without it.
+func weightToTf(prior types.Int64, weight *trinogatewayclient.Number) types.Int64 {
+	unset := prior.IsNull() || prior.IsUnknown()
+	if weight == nil || (*weight == 0 && unset) {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*weight))
+}
+
+// tfToWeight returns nil for unset weight, so it is not sent to gateway, explicit zero is kept.
+func tfToWeight(value types.Int64) *trinogatewayclient.Number {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	weight := trinogatewayclient.Number(value.ValueInt64())
+	return &weight
 }
@@ -0,0 +1,958 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestFindImportedBackend(t *testing.T) {
+	backends := []*trinogatewayclient.Backend{
+		{Name: "trino-1", RoutingGroup: "adhoc"},
+		{Name: "team/trino-2", RoutingGroup: "etl"},
+		{Name: "trino-3", RoutingGroup: "team"},
+	}
+	tests := []struct {
+		name     string
+		id       string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "by name", id: "trino-1", wantName: "trino-1"},
+		{name: "by routing group and name", id: "adhoc/trino-1", wantName: "trino-1"},
+		{name: "name with slash", id: "team/trino-2", wantName: "team/trino-2"},
+		{name: "routing group mismatch", id: "etl/trino-1", wantErr: true},
+		{name: "not found", id: "trino-4", wantErr: true},
+		{name: "not found with routing group", id: "adhoc/trino-4", wantErr: true},
+		{name: "routing group of name with slash", id: "etl/team/trino-2", wantName: "team/trino-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, diags := findImportedBackend(backends, tt.id)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got errors %v, want error: %v", diags, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if backend == nil || backend.Name != tt.wantName {
+				t.Fatalf("got backend %v, want %q", backend, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAvailableImportIds(t *testing.T) {
+	many := []*trinogatewayclient.Backend{}
+	for i := maxListedImportIds + 2; i > 0; i-- {
+		many = append(many, &trinogatewayclient.Backend{Name: fmt.Sprintf("trino-%02d", i)})
+	}
+	tests := []struct {
+		name     string
+		backends []*trinogatewayclient.Backend
+		want     string
+	}{
+		{name: "no backends", want: "none, gateway has no backends"},
+		{name: "few", backends: []*trinogatewayclient.Backend{{Name: "trino-2"}, {Name: "trino-1"}}, want: "trino-1, trino-2"},
+		{name: "many", backends: many, want: "trino-01, trino-02, trino-03, trino-04, trino-05, trino-06, trino-07, trino-08, trino-09, trino-10, " +
+			"trino-11, trino-12, trino-13, trino-14, trino-15, trino-16, trino-17, trino-18, trino-19, trino-20 and 2 more"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := availableImportIds(tt.backends); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendImportNotFound(t *testing.T) {
+	_, gatewayServer := newFakeGateway(t,
+		trinogatewayclient.Backend{Name: "trino-2", RoutingGroup: "adhoc"},
+		trinogatewayclient.Backend{Name: "trino-1", RoutingGroup: "adhoc"},
+	)
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+	})
+	resp, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: "trinogateway_backend",
+		ID:       "trino-3",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Backend not found" {
+		t.Fatalf("got diagnostics %+v, want not found error", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics[0].Detail; !strings.HasSuffix(detail, "available ids: trino-1, trino-2") {
+		t.Fatalf("detail has no available ids: %s", detail)
+	}
+}
+
+func TestBackendDefaultRoutingGroup(t *testing.T) {
+	tests := []struct {
+		name                string
+		defaultRoutingGroup string
+		routingGroup        string
+		want                string
+	}{
+		{name: "provider default", defaultRoutingGroup: "adhoc", want: "adhoc"},
+		{name: "configured", defaultRoutingGroup: "adhoc", routingGroup: "etl", want: "etl"},
+		{name: "no default", routingGroup: "etl", want: "etl"},
+		{name: "nothing set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"name":     tftypes.NewValue(tftypes.String, "trino-1"),
+				"proxy_to": tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+			}
+			if tt.routingGroup != "" {
+				config["routing_group"] = tftypes.NewValue(tftypes.String, tt.routingGroup)
+			}
+			resp := planCreate(t, &BackendResource{}, &TrinoGatewayProviderData{DefaultRoutingGroup: tt.defaultRoutingGroup}, config)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var routingGroup types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), path.Root("routing_group"), &routingGroup)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if routingGroup.ValueString() != tt.want {
+				t.Fatalf("planned routing group %q, want %q", routingGroup.ValueString(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendCreateWithoutRoutingGroup(t *testing.T) {
+	resp := create(t, &BackendResource{}, &TrinoGatewayProviderData{}, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "trino-1"),
+		"proxy_to": tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+	})
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Routing group is not set" {
+		t.Fatalf("got %v, want routing group error", resp.Diagnostics)
+	}
+}
+
+func TestUrlSchemesMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		a    types.String
+		b    types.String
+		want bool
+	}{
+		{name: "same scheme", a: types.StringValue("https://trino-1:8443"), b: types.StringValue("https://trino.example.com"), want: true},
+		{name: "scheme case", a: types.StringValue("HTTPS://trino-1:8443"), b: types.StringValue("https://trino.example.com"), want: true},
+		{name: "mismatch", a: types.StringValue("http://trino-1:8080"), b: types.StringValue("https://trino.example.com"), want: false},
+		{name: "null", a: types.StringValue("http://trino-1:8080"), b: types.StringNull(), want: true},
+		{name: "unknown", a: types.StringUnknown(), b: types.StringValue("https://trino.example.com"), want: true},
+		{name: "unparsable", a: types.StringValue("http://trino-1:port"), b: types.StringValue("https://trino.example.com"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := urlSchemesMatch(tt.a, tt.b); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendUrlSchemeMismatchWarning(t *testing.T) {
+	resp := planCreate(t, &BackendResource{}, &TrinoGatewayProviderData{WarnUrlSchemeMismatch: true}, map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, "trino-1"),
+		"proxy_to":      tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+		"external_url":  tftypes.NewValue(tftypes.String, "https://trino.example.com"),
+		"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if warnings := resp.Diagnostics.Warnings(); len(warnings) != 1 || warnings[0].Summary() != "Url scheme mismatch" {
+		t.Fatalf("got %v, want scheme mismatch warning", resp.Diagnostics)
+	}
+}
+
+func TestBackendRecreateMissing(t *testing.T) {
+	for _, recreateMissing := range []bool{false, true} {
+		t.Run(fmt.Sprintf("recreate_missing=%v", recreateMissing), func(t *testing.T) {
+			ctx := context.Background()
+			gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer gateway.Close()
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint":         tftypes.NewValue(tftypes.String, gateway.URL),
+				"recreate_missing": tftypes.NewValue(tftypes.Bool, recreateMissing),
+			})
+			objectType := server.resourceType(t, "trinogateway_backend")
+			config := map[string]tftypes.Value{
+				"name":          tftypes.NewValue(tftypes.String, "trino-1"),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+				"active":        tftypes.NewValue(tftypes.Bool, true),
+			}
+			state := map[string]tftypes.Value{"id": tftypes.NewValue(tftypes.String, "trino-1")}
+			for name, value := range config {
+				state[name] = value
+			}
+
+			readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "trinogateway_backend",
+				CurrentState: dynamicValue(t, objectType, state),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, readResp.Diagnostics)
+			newState, err := readResp.NewState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !recreateMissing {
+				if !newState.IsNull() {
+					t.Fatal("missing backend is kept in state")
+				}
+				return
+			}
+			if newState.IsNull() {
+				t.Fatal("missing backend is removed from state")
+			}
+
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "trinogateway_backend",
+				PriorState:       readResp.NewState,
+				ProposedNewState: readResp.NewState,
+				Config:           dynamicValue(t, objectType, config),
+				PriorPrivate:     readResp.Private,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, planResp.Diagnostics)
+			if len(planResp.RequiresReplace) != 1 || !planResp.RequiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("id")) {
+				t.Fatalf("got requires replace %v, want id", planResp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestCheckNotLastActive(t *testing.T) {
+	r := &BackendResource{}
+	client := backendsGateway(t, `[
+		{"name":"adhoc-1","routingGroup":"adhoc","active":true},
+		{"name":"adhoc-2","routingGroup":"adhoc","active":true},
+		{"name":"etl-1","routingGroup":"etl","active":true},
+		{"name":"etl-2","routingGroup":"etl","active":false}
+	]`)
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "adhoc-1"},
+		{name: "etl-1", wantErr: true},
+		{name: "etl-2"},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := r.checkNotLastActive(context.Background(), client, tt.name, "delete")
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBackendUpdateMovingLastActive(t *testing.T) {
+	tests := []struct {
+		name      string
+		backend   string
+		active    bool
+		wantError bool
+	}{
+		{name: "last active", backend: "etl-1", active: true, wantError: true},
+		{name: "other active left", backend: "adhoc-1", active: true},
+		{name: "inactive", backend: "etl-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, gatewayServer := newFakeGateway(t,
+				trinogatewayclient.Backend{Name: "adhoc-1", ProxyTo: "http://adhoc-1", ExternalUrl: "http://adhoc-1", RoutingGroup: "adhoc", Active: true},
+				trinogatewayclient.Backend{Name: "adhoc-2", ProxyTo: "http://adhoc-2", ExternalUrl: "http://adhoc-2", RoutingGroup: "adhoc", Active: true},
+				trinogatewayclient.Backend{Name: "etl-1", ProxyTo: "http://etl-1", ExternalUrl: "http://etl-1", RoutingGroup: "etl", Active: true},
+				trinogatewayclient.Backend{Name: "etl-2", ProxyTo: "http://etl-2", ExternalUrl: "http://etl-2", RoutingGroup: "etl"},
+			)
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint":                   tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				"prevent_last_active_delete": tftypes.NewValue(tftypes.Bool, true),
+			})
+			objectType := server.resourceType(t, "trinogateway_backend")
+			routingGroup := strings.Split(tt.backend, "-")[0]
+			prior := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, tt.backend),
+				"name":          tftypes.NewValue(tftypes.String, tt.backend),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://"+tt.backend),
+				"external_url":  tftypes.NewValue(tftypes.String, "http://"+tt.backend),
+				"routing_group": tftypes.NewValue(tftypes.String, routingGroup),
+				"active":        tftypes.NewValue(tftypes.Bool, tt.active),
+			}
+			planned := map[string]tftypes.Value{}
+			for name, value := range prior {
+				planned[name] = value
+			}
+			planned["routing_group"] = tftypes.NewValue(tftypes.String, "reporting")
+
+			resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "trinogateway_backend",
+				PriorState:   dynamicValue(t, objectType, prior),
+				PlannedState: dynamicValue(t, objectType, planned),
+				Config:       dynamicValue(t, objectType, planned),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantError {
+				checkDiagnostics(t, resp.Diagnostics)
+				if got := gateway.Writes(); !slices.Equal(got, []string{"update " + tt.backend}) {
+					t.Fatalf("gateway got writes %q, want update", got)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Blocked by provider guard" {
+				t.Fatalf("got diagnostics %+v, want guard error", resp.Diagnostics)
+			}
+			if got := len(gateway.Writes()); got != 0 {
+				t.Fatalf("gateway got %d writes, want none", got)
+			}
+		})
+	}
+}
+
+func TestBackendGuardsUseBackendAuth(t *testing.T) {
+	tests := []struct {
+		name  string
+		guard string
+		value tftypes.Value
+	}{
+		{name: "last active", guard: "prevent_last_active_delete", value: tftypes.NewValue(tftypes.Bool, true)},
+		{name: "orphan check", guard: "orphan_check", value: tftypes.NewValue(tftypes.String, orphanCheckError)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, _ := newFakeGateway(t,
+				trinogatewayclient.Backend{Name: "etl-1", ProxyTo: "http://etl-1", ExternalUrl: "http://etl-1", RoutingGroup: "etl", Active: true},
+			)
+			gateway.SetDefaultRoutingGroup("etl")
+			// only backend auth override is accepted by gateway
+			gatewayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-API-Key") != "backend-key" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				gateway.ServeHTTP(w, r)
+			}))
+			defer gatewayServer.Close()
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				tt.guard:   tt.value,
+			})
+			objectType := server.resourceType(t, "trinogateway_backend")
+			prior := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, "etl-1"),
+				"name":          tftypes.NewValue(tftypes.String, "etl-1"),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://etl-1"),
+				"external_url":  tftypes.NewValue(tftypes.String, "http://etl-1"),
+				"routing_group": tftypes.NewValue(tftypes.String, "etl"),
+				"active":        tftypes.NewValue(tftypes.Bool, true),
+				"auth": objectValue(objectType.AttributeTypes["auth"].(tftypes.Object), map[string]tftypes.Value{
+					"api_key": tftypes.NewValue(tftypes.String, "backend-key"),
+				}),
+			}
+			destroyed, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "trinogateway_backend",
+				PriorState:   dynamicValue(t, objectType, prior),
+				PlannedState: &destroyed,
+				Config:       &destroyed,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Blocked by provider guard" {
+				t.Fatalf("got diagnostics %+v, want guard error", resp.Diagnostics)
+			}
+			if got := len(gateway.Writes()); got != 0 {
+				t.Fatalf("gateway got %d writes, want none", got)
+			}
+		})
+	}
+}
+
+func TestBackendUpdateSkipsUnchanged(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxyTo    string
+		wantWrites int
+	}{
+		{name: "unchanged", proxyTo: "http://trino-1:8080", wantWrites: 0},
+		{name: "changed", proxyTo: "http://trino-1:8081", wantWrites: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, gatewayServer := newFakeGateway(t, trinogatewayclient.Backend{
+				Name:         "trino-1",
+				ProxyTo:      "http://trino-1:8080",
+				ExternalUrl:  "http://trino-1:8080",
+				RoutingGroup: "adhoc",
+				Active:       true,
+			})
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+			})
+			objectType := server.resourceType(t, "trinogateway_backend")
+			prior := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, "trino-1"),
+				"name":          tftypes.NewValue(tftypes.String, "trino-1"),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"external_url":  tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+				"active":        tftypes.NewValue(tftypes.Bool, true),
+			}
+			planned := map[string]tftypes.Value{}
+			for name, value := range prior {
+				planned[name] = value
+			}
+			planned["proxy_to"] = tftypes.NewValue(tftypes.String, tt.proxyTo)
+
+			resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "trinogateway_backend",
+				PriorState:   dynamicValue(t, objectType, prior),
+				PlannedState: dynamicValue(t, objectType, planned),
+				Config:       dynamicValue(t, objectType, planned),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, resp.Diagnostics)
+			if got := len(gateway.Writes()); got != tt.wantWrites {
+				t.Fatalf("gateway got %d writes, want %d", got, tt.wantWrites)
+			}
+		})
+	}
+}
+
+func TestBackendGracefulDeactivate(t *testing.T) {
+	tests := []struct {
+		name      string
+		graceful  bool
+		proxyTo   string
+		wantWrite string
+	}{
+		{name: "graceful", graceful: true, proxyTo: "http://trino-1:8080", wantWrite: "deactivate trino-1"},
+		{name: "graceful with other changes", graceful: true, proxyTo: "http://trino-1:8081", wantWrite: "update trino-1"},
+		{name: "disabled", proxyTo: "http://trino-1:8080", wantWrite: "update trino-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, gatewayServer := newFakeGateway(t, trinogatewayclient.Backend{
+				Name:         "trino-1",
+				ProxyTo:      "http://trino-1:8080",
+				ExternalUrl:  "http://trino-1:8080",
+				RoutingGroup: "adhoc",
+				Active:       true,
+			})
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint":                tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				"use_graceful_deactivate": tftypes.NewValue(tftypes.Bool, tt.graceful),
+			})
+			objectType := server.resourceType(t, "trinogateway_backend")
+			prior := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, "trino-1"),
+				"name":          tftypes.NewValue(tftypes.String, "trino-1"),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"external_url":  tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+				"active":        tftypes.NewValue(tftypes.Bool, true),
+			}
+			planned := map[string]tftypes.Value{}
+			for name, value := range prior {
+				planned[name] = value
+			}
+			planned["proxy_to"] = tftypes.NewValue(tftypes.String, tt.proxyTo)
+			planned["active"] = tftypes.NewValue(tftypes.Bool, false)
+
+			resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "trinogateway_backend",
+				PriorState:   dynamicValue(t, objectType, prior),
+				PlannedState: dynamicValue(t, objectType, planned),
+				Config:       dynamicValue(t, objectType, planned),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, resp.Diagnostics)
+			if got := gateway.Writes(); !slices.Equal(got, []string{tt.wantWrite}) {
+				t.Fatalf("gateway got writes %q, want %q", got, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestOnlyDeactivated(t *testing.T) {
+	current := &trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc", Active: true}
+	tests := []struct {
+		name    string
+		current *trinogatewayclient.Backend
+		desired trinogatewayclient.Backend
+		want    bool
+	}{
+		{name: "deactivated", current: current, desired: trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc"}, want: true},
+		{name: "other changes", current: current, desired: trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "etl"}},
+		{name: "still active", current: current, desired: *current},
+		{name: "already inactive", current: &trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc"}, desired: trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc"}},
+		{name: "missing", desired: trinogatewayclient.Backend{Name: "trino-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := onlyDeactivated(tt.current, &tt.desired); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendImportConsistency(t *testing.T) {
+	for _, changed := range []bool{false, true} {
+		t.Run(fmt.Sprintf("changed=%v", changed), func(t *testing.T) {
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			backend := trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc", Active: true}
+			gateway, gatewayServer := newFakeGateway(t, backend, trinogatewayclient.Backend{Name: "trino-2", ProxyTo: "http://trino-2:8080", RoutingGroup: "adhoc"})
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+			})
+			// refresh of another backend loads backends snapshot before import
+			readOtherResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName: "trinogateway_backend",
+				CurrentState: dynamicValue(t, server.resourceType(t, "trinogateway_backend"), map[string]tftypes.Value{
+					"id":   tftypes.NewValue(tftypes.String, "trino-2"),
+					"name": tftypes.NewValue(tftypes.String, "trino-2"),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, readOtherResp.Diagnostics)
+
+			if changed {
+				backend.Active = false
+				gateway.Set(backend)
+			}
+			importResp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+				TypeName: "trinogateway_backend",
+				ID:       "trino-1",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, importResp.Diagnostics)
+			if len(importResp.ImportedResources) != 1 {
+				t.Fatalf("got %d imported resources, want 1", len(importResp.ImportedResources))
+			}
+			imported := importResp.ImportedResources[0]
+
+			readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "trinogateway_backend",
+				CurrentState: imported.State,
+				Private:      imported.Private,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, readResp.Diagnostics)
+			if got := strings.Contains(output.String(), "backend read after import differs from imported one"); got != changed {
+				t.Fatalf("inconsistency logged: %v, want %v: %s", got, changed, output.String())
+			}
+		})
+	}
+}
+
+func TestBackendImportSetsId(t *testing.T) {
+	_, gatewayServer := newFakeGateway(t,
+		trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1", ExternalUrl: "http://trino-1", RoutingGroup: "adhoc", Active: true},
+	)
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+	})
+	objectType := server.resourceType(t, "trinogateway_backend")
+	for _, id := range []string{"trino-1", "adhoc/trino-1"} {
+		t.Run(id, func(t *testing.T) {
+			resp, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+				TypeName: "trinogateway_backend",
+				ID:       id,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, resp.Diagnostics)
+			if len(resp.ImportedResources) != 1 {
+				t.Fatalf("got %d imported resources, want 1", len(resp.ImportedResources))
+			}
+			state, err := resp.ImportedResources[0].State.Unmarshal(objectType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]tftypes.Value{}
+			if err := state.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			var got string
+			if err := attributes["id"].As(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got != "trino-1" {
+				t.Fatalf("got id %q, want backend name", got)
+			}
+		})
+	}
+}
+
+func TestBackendExternalUrlDefault(t *testing.T) {
+	tests := []struct {
+		mode      string
+		wantState types.String
+	}{
+		{mode: externalUrlDefaultMirrorProxyTo, wantState: types.StringValue("http://trino-1:8080")},
+		{mode: externalUrlDefaultNull, wantState: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			r := &BackendResource{providerData: &TrinoGatewayProviderData{ExternalUrlDefault: tt.mode}}
+			data := BackendResourceModel{ProxyTo: types.StringValue("http://trino-1:8080"), ExternalUrl: types.StringNull()}
+			r.defaultExternalUrl(&data)
+			if !data.ExternalUrl.Equal(tt.wantState) {
+				t.Fatalf("got external_url %s, want %s", data.ExternalUrl, tt.wantState)
+			}
+
+			explicit := BackendResourceModel{ProxyTo: types.StringValue("http://trino-1:8080"), ExternalUrl: types.StringValue("https://trino.example.com")}
+			r.defaultExternalUrl(&explicit)
+			if explicit.ExternalUrl.ValueString() != "https://trino.example.com" {
+				t.Fatalf("explicit external_url changed to %s", explicit.ExternalUrl)
+			}
+		})
+	}
+}
+
+func TestBackendReadExternalUrl(t *testing.T) {
+	tests := []struct {
+		name               string
+		mode               string
+		prior              types.String
+		gatewayExternalUrl string
+		want               types.String
+	}{
+		{name: "mirrored", mode: externalUrlDefaultMirrorProxyTo, prior: types.StringValue("http://trino-1:8080"), gatewayExternalUrl: "http://trino-1:8080/", want: types.StringValue("http://trino-1:8080")},
+		{name: "null mode, gateway keeps empty", mode: externalUrlDefaultNull, prior: types.StringNull(), gatewayExternalUrl: "", want: types.StringNull()},
+		{name: "null mode, gateway mirrors proxy_to", mode: externalUrlDefaultNull, prior: types.StringNull(), gatewayExternalUrl: "http://trino-1:8080/", want: types.StringNull()},
+		{name: "null mode, set outside", mode: externalUrlDefaultNull, prior: types.StringNull(), gatewayExternalUrl: "https://trino.example.com", want: types.StringValue("https://trino.example.com")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BackendResource{providerData: &TrinoGatewayProviderData{ExternalUrlDefault: tt.mode}}
+			backend := &trinogatewayclient.Backend{ProxyTo: "http://trino-1:8080", ExternalUrl: tt.gatewayExternalUrl}
+			if got := r.readExternalUrl(tt.prior, backend); !got.Equal(tt.want) {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitBackendDeleted(t *testing.T) {
+	tests := []struct {
+		name string
+		// listsWithBackend is number of list responses still having deleted backend
+		listsWithBackend int32
+		timeout          time.Duration
+		wantErr          string
+	}{
+		{name: "deleted", timeout: 5 * time.Second},
+		{name: "deleted asynchronously", listsWithBackend: 1, timeout: 5 * time.Second},
+		{name: "still present", listsWithBackend: 1000, timeout: 100 * time.Millisecond, wantErr: "Backend deletion is not confirmed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lists atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if lists.Add(1) <= tt.listsWithBackend {
+					_, _ = w.Write([]byte(`[{"name":"trino-1","proxyTo":"http://trino-1:8080","routingGroup":"adhoc","active":true}]`))
+					return
+				}
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			diags := waitBackendDeleted(ctx, newGatewayClient(t, server), "trino-1")
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Fatal(diags)
+				}
+				if got := lists.Load(); got != tt.listsWithBackend+1 {
+					t.Fatalf("gateway listed %d times, want %d", got, tt.listsWithBackend+1)
+				}
+				return
+			}
+			if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+				t.Fatalf("got %v, want error %q", diags, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckNotOrphaningDefaultRoutingGroup(t *testing.T) {
+	tests := []struct {
+		name                string
+		defaultRoutingGroup string
+		mode                string
+		backend             string
+		wantErr             bool
+		wantWarning         bool
+	}{
+		{name: "last backend, warn", defaultRoutingGroup: "etl", mode: orphanCheckWarn, backend: "etl-1", wantWarning: true},
+		{name: "last backend, error", defaultRoutingGroup: "etl", mode: orphanCheckError, backend: "etl-1", wantErr: true},
+		{name: "other backends left", defaultRoutingGroup: "adhoc", mode: orphanCheckError, backend: "adhoc-1"},
+		{name: "not in default group", defaultRoutingGroup: "adhoc", mode: orphanCheckError, backend: "etl-1"},
+		{name: "no default group", mode: orphanCheckError, backend: "etl-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, server := newFakeGateway(t,
+				trinogatewayclient.Backend{Name: "adhoc-1", RoutingGroup: "adhoc", Active: true},
+				trinogatewayclient.Backend{Name: "adhoc-2", RoutingGroup: "adhoc", Active: true},
+				trinogatewayclient.Backend{Name: "etl-1", RoutingGroup: "etl", Active: true},
+			)
+			gateway.SetDefaultRoutingGroup(tt.defaultRoutingGroup)
+			r := &BackendResource{providerData: &TrinoGatewayProviderData{OrphanCheck: tt.mode}}
+			diags := r.checkNotOrphaningDefaultRoutingGroup(context.Background(), newGatewayClient(t, server), tt.backend)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", diags, tt.wantErr)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Fatalf("got %v, want warning: %v", diags, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestFindBackendDuplicatedName(t *testing.T) {
+	backends := []*trinogatewayclient.Backend{
+		{Name: "trino-1", ProxyTo: "http://trino-1:8080"},
+		{Name: "trino-2", ProxyTo: "http://trino-2:8080"},
+		{Name: "trino-1", ProxyTo: "http://trino-1:8081"},
+	}
+	tests := []struct {
+		name        string
+		wantProxyTo string
+		wantWarning bool
+	}{
+		{name: "trino-1", wantProxyTo: "http://trino-1:8081", wantWarning: true},
+		{name: "trino-2", wantProxyTo: "http://trino-2:8080"},
+		{name: "missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, diags := findBackend(backends, tt.name)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Fatalf("got %v, want warning: %v", diags, tt.wantWarning)
+			}
+			if tt.wantProxyTo == "" {
+				if backend != nil {
+					t.Fatalf("got backend %+v, want none", *backend)
+				}
+				return
+			}
+			if backend == nil || backend.ProxyTo != tt.wantProxyTo {
+				t.Fatalf("got backend %+v, want proxy_to %s", backend, tt.wantProxyTo)
+			}
+		})
+	}
+}
+
+func TestBackendImportDuplicatedName(t *testing.T) {
+	gatewayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name":"trino-1","proxyTo":"http://trino-1:8080","routingGroup":"adhoc","active":true},
+			{"name":"trino-1","proxyTo":"http://trino-1:8081","routingGroup":"adhoc","active":true}
+		]`))
+	}))
+	defer gatewayServer.Close()
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+	})
+	resp, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: "trinogateway_backend",
+		ID:       "trino-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, resp.Diagnostics)
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Duplicated backend name" {
+		t.Fatalf("got diagnostics %+v, want duplicated name warning", resp.Diagnostics)
+	}
+}
+
+func TestBackendImportExternalUrl(t *testing.T) {
+	tests := []struct {
+		name              string
+		externalUrlMode   string
+		importExternalUrl string
+		want              tftypes.Value
+	}{
+		{name: "defaulted, mirror", externalUrlMode: externalUrlDefaultMirrorProxyTo, importExternalUrl: importExternalUrlDefaulted, want: tftypes.NewValue(tftypes.String, "http://trino-1:8080")},
+		{name: "defaulted, null", externalUrlMode: externalUrlDefaultNull, importExternalUrl: importExternalUrlDefaulted, want: tftypes.NewValue(tftypes.String, nil)},
+		{name: "explicit, null", externalUrlMode: externalUrlDefaultNull, importExternalUrl: importExternalUrlExplicit, want: tftypes.NewValue(tftypes.String, "http://trino-1:8080")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, gatewayServer := newFakeGateway(t, trinogatewayclient.Backend{
+				Name:         "trino-1",
+				ProxyTo:      "http://trino-1:8080",
+				ExternalUrl:  "http://trino-1:8080",
+				RoutingGroup: "adhoc",
+				Active:       true,
+			})
+			server := newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint":             tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				"external_url_default": tftypes.NewValue(tftypes.String, tt.externalUrlMode),
+				"import_external_url":  tftypes.NewValue(tftypes.String, tt.importExternalUrl),
+			})
+			resp, err := server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+				TypeName: "trinogateway_backend",
+				ID:       "trino-1",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, resp.Diagnostics)
+			state, err := resp.ImportedResources[0].State.Unmarshal(server.resourceType(t, "trinogateway_backend"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]tftypes.Value{}
+			if err := state.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			if got := attributes["external_url"]; !got.Equal(tt.want) {
+				t.Fatalf("got external_url %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendRoutingGroupMemberCount(t *testing.T) {
+	_, gatewayServer := newFakeGateway(t,
+		trinogatewayclient.Backend{Name: "adhoc-1", ProxyTo: "http://adhoc-1:8080", RoutingGroup: "adhoc", Active: true},
+		trinogatewayclient.Backend{Name: "adhoc-2", ProxyTo: "http://adhoc-2:8080", RoutingGroup: "adhoc"},
+		trinogatewayclient.Backend{Name: "etl-1", ProxyTo: "http://etl-1:8080", RoutingGroup: "etl", Active: true},
+	)
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+	})
+	objectType := server.resourceType(t, "trinogateway_backend")
+	tests := []struct {
+		name string
+		want int64
+	}{
+		{name: "adhoc-1", want: 2},
+		{name: "etl-1", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName: "trinogateway_backend",
+				CurrentState: dynamicValue(t, objectType, map[string]tftypes.Value{
+					"id":   tftypes.NewValue(tftypes.String, tt.name),
+					"name": tftypes.NewValue(tftypes.String, tt.name),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, resp.Diagnostics)
+			state, err := resp.NewState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]tftypes.Value{}
+			if err := state.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := attributes["routing_group_member_count"], tftypes.NewValue(tftypes.Number, tt.want); !got.Equal(want) {
+				t.Fatalf("got routing_group_member_count %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendValidateProxyTo(t *testing.T) {
+	schemaResp, objectType := configuredResource(t, &BackendResource{}, &TrinoGatewayProviderData{})
+	tests := []struct {
+		name    string
+		active  bool
+		proxyTo tftypes.Value
+		wantErr bool
+	}{
+		{name: "valid", active: true, proxyTo: tftypes.NewValue(tftypes.String, "https://trino-1:8443")},
+		{name: "no scheme", active: true, proxyTo: tftypes.NewValue(tftypes.String, "trino-1:8080"), wantErr: true},
+		{name: "other scheme", active: true, proxyTo: tftypes.NewValue(tftypes.String, "ftp://trino-1"), wantErr: true},
+		{name: "no host", active: true, proxyTo: tftypes.NewValue(tftypes.String, "http://"), wantErr: true},
+		{name: "empty", active: true, proxyTo: tftypes.NewValue(tftypes.String, ""), wantErr: true},
+		{name: "unknown", active: true, proxyTo: tftypes.NewValue(tftypes.String, tftypes.UnknownValue)},
+		{name: "inactive placeholder", proxyTo: tftypes.NewValue(tftypes.String, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ValidateConfigResponse{}
+			(&BackendResource{}).ValidateConfig(context.Background(), resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(objectType, map[string]tftypes.Value{
+					"name":     tftypes.NewValue(tftypes.String, "trino-1"),
+					"active":   tftypes.NewValue(tftypes.Bool, tt.active),
+					"proxy_to": tt.proxyTo,
+				})},
+			}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", resp.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+const (
+	// backendSetId is the only id of singleton resource
+	backendSetId = "backends"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BackendSetResource{}
+var _ resource.ResourceWithImportState = &BackendSetResource{}
+
+func NewBackendSetResource() resource.Resource {
+	return &BackendSetResource{}
+}
+
+// BackendSetResource manages all gateway backends at once.
+type BackendSetResource struct {
+	client  trinogatewayclient.TrinoGatewayClient
+	journal *trinogatewayclient.Journal
+
+	preventLastActiveDelete bool
+}
+
+// BackendSetResourceModel describes the resource data model.
+type BackendSetResourceModel struct {
+	Id       types.String           `tfsdk:"id"`
+	Backends []BackendSetEntryModel `tfsdk:"backends"`
+}
+
+type BackendSetEntryModel struct {
+	Name         types.String `tfsdk:"name"`
+	ProxyTo      types.String `tfsdk:"proxy_to"`
+	Active       types.Bool   `tfsdk:"active"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+	ExternalUrl  types.String `tfsdk:"external_url"`
+	Weight       types.Int64  `tfsdk:"weight"`
+}
+
+func (r *BackendSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_set"
+}
+
+func (r *BackendSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Set of gateway backends managed as one. Backends removed from `backends` are deleted from gateway. " +
+			"Only backends the set created or imported are reconciled, other gateway backends, like `trinogateway_backend` ones, are left as is. " +
+			"Import takes all gateway backends. Destroying the resource deletes its backends",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Always `backends`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"backends": schema.ListNestedAttribute{
+				MarkdownDescription: "All gateway backends",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of backend",
+							Required:            true,
+						},
+						"proxy_to": schema.StringAttribute{
+							MarkdownDescription: "Backend url",
+							Required:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Backend activation",
+							Required:            true,
+						},
+						"routing_group": schema.StringAttribute{
+							MarkdownDescription: "Routing group name",
+							Required:            true,
+						},
+						"external_url": schema.StringAttribute{
+							MarkdownDescription: "External backend url. Gateway gets `proxy_to` if not set",
+							Optional:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "Weight for routing inside routing group. Not part of upstream Trino Gateway backend entity, for gateways with weighted routing. Sent only when set, explicit 0 is sent",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *BackendSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.journal = providerData.Journal
+	r.preventLastActiveDelete = providerData.PreventLastActiveDelete
+}
+
+func (r *BackendSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data BackendSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, nil, data.Backends)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(backendSetId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackendSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backends, err := r.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+
+	// backends not owned by the set, like standalone trinogateway_backend ones, are not drift
+	owned := backendSetEntryNames(data.Backends)
+	backends = slices.DeleteFunc(slices.Clone(backends), func(backend *trinogatewayclient.Backend) bool {
+		_, ok := owned[backend.Name]
+		return !ok
+	})
+
+	data.Id = types.StringValue(backendSetId)
+	data.Backends = backendSetEntriesFromDomain(data.Backends, backends)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data BackendSetResourceModel
+
+	var prior BackendSetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, prior.Backends, data.Backends)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BackendSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data BackendSetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.preventLastActiveDelete {
+		deleted := backendSetEntryNames(data.Backends)
+		resp.Diagnostics.Append(checkNoRoutingGroupLosesLastActive(ctx, r.client, func(current []*trinogatewayclient.Backend) []*trinogatewayclient.Backend {
+			var desired []*trinogatewayclient.Backend
+			for _, backend := range current {
+				if _, ok := deleted[backend.Name]; !ok {
+					desired = append(desired, backend)
+				}
+			}
+			return desired
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// only backends of the set are deleted, ones registered after last apply are left as is
+	names := make([]string, 0, len(data.Backends))
+	for _, entry := range data.Backends {
+		names = append(names, entry.Name.ValueString())
+	}
+	if err := r.client.DeleteBackends(ctx, names); err != nil {
+		addMutationError(&resp.Diagnostics, r.journal, "Unable to delete backends", err)
+		return
+	}
+}
+
+func (r *BackendSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != backendSetId {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Backend set can be imported only by id %q, got %q", backendSetId, req.ID),
+		)
+		return
+	}
+
+	backends, err := r.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+	data := BackendSetResourceModel{
+		Id:       types.StringValue(backendSetId),
+		Backends: backendSetEntriesFromDomain(nil, backends),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// reconcile makes gateway backends equal to entries. Only backends of prior and planned entries are owned by the set,
+// other ones are not deleted.
+func (r *BackendSetResource) reconcile(ctx context.Context, prior []BackendSetEntryModel, entries []BackendSetEntryModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	desired := make([]*trinogatewayclient.Backend, 0, len(entries))
+	for _, entry := range entries {
+		backend := &trinogatewayclient.Backend{
+			Name:         entry.Name.ValueString(),
+			ProxyTo:      entry.ProxyTo.ValueString(),
+			Active:       entry.Active.ValueBool(),
+			RoutingGroup: entry.RoutingGroup.ValueString(),
+			ExternalUrl:  entry.ExternalUrl.ValueString(),
+			Weight:       tfToWeight(entry.Weight),
+		}
+		if entry.ExternalUrl.IsNull() {
+			backend.ExternalUrl = backend.ProxyTo
+		}
+		desired = append(desired, backend)
+	}
+	owned := backendSetEntryNames(prior)
+	for name := range backendSetEntryNames(entries) {
+		owned[name] = struct{}{}
+	}
+	if r.preventLastActiveDelete {
+		diags.Append(checkNoRoutingGroupLosesLastActive(ctx, r.client, func(current []*trinogatewayclient.Backend) []*trinogatewayclient.Backend {
+			desiredWithNotOwned := slices.Clone(desired)
+			for _, backend := range current {
+				if _, ok := owned[backend.Name]; !ok {
+					desiredWithNotOwned = append(desiredWithNotOwned, backend)
+				}
+			}
+			return desiredWithNotOwned
+		})...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	ownedNames := make([]string, 0, len(owned))
+	for name := range owned {
+		ownedNames = append(ownedNames, name)
+	}
+	summary, err := r.client.ReconcileBackends(ctx, desired, ownedNames)
+	if err != nil {
+		addMutationError(&diags, r.journal, "Unable to reconcile backends", err)
+		return diags
+	}
+	tflog.Debug(ctx, "backends reconciled", map[string]interface{}{
+		"added":   summary.Added,
+		"updated": summary.Updated,
+		"deleted": summary.Deleted,
+	})
+	return diags
+}
+
+func backendSetEntryNames(entries []BackendSetEntryModel) map[string]struct{} {
+	names := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		names[entry.Name.ValueString()] = struct{}{}
+	}
+	return names
+}
+
+// backendSetEntriesFromDomain keeps order of prior entries, so reordering on gateway gives no diff.
+// Backends unknown to prior state are appended sorted by name.
+func backendSetEntriesFromDomain(prior []BackendSetEntryModel, backends []*trinogatewayclient.Backend) []BackendSetEntryModel {
+	byName := make(map[string]*trinogatewayclient.Backend, len(backends))
+	for _, backend := range backends {
+		byName[backend.Name] = backend
+	}
+
+	entries := []BackendSetEntryModel{}
+	for _, entry := range prior {
+		backend, ok := byName[entry.Name.ValueString()]
+		if !ok {
+			continue
+		}
+		delete(byName, backend.Name)
+		entries = append(entries, backendSetEntryFromDomain(entry, backend))
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		entries = append(entries, backendSetEntryFromDomain(BackendSetEntryModel{}, byName[name]))
+	}
+	return entries
+}
+
+func backendSetEntryFromDomain(prior BackendSetEntryModel, backend *trinogatewayclient.Backend) BackendSetEntryModel {
+	externalUrl := preserveEquivalentUrl(prior.ExternalUrl, backend.ExternalUrl)
+	// unset external_url was sent as proxy_to
+	if prior.ExternalUrl.IsNull() && (backend.ExternalUrl == "" || urlsEquivalent(backend.ExternalUrl, backend.ProxyTo)) {
+		externalUrl = types.StringNull()
+	}
+	return BackendSetEntryModel{
+		Name:         types.StringValue(backend.Name),
+		ProxyTo:      preserveEquivalentUrl(prior.ProxyTo, backend.ProxyTo),
+		Active:       types.BoolValue(backend.Active),
+		RoutingGroup: types.StringValue(backend.RoutingGroup),
+		ExternalUrl:  externalUrl,
+		Weight:       weightToTf(prior.Weight, backend.Weight),
+	}
+}
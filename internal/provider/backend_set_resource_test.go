@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestBackendSetImportState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"name":"etl-1","proxyTo":"http://etl-1","routingGroup":"etl","active":true,"externalUrl":"https://etl","weight":3},
+			{"name":"adhoc-1","proxyTo":"http://adhoc-1","routingGroup":"adhoc","active":false,"externalUrl":"http://adhoc-1"}
+		]`))
+	}))
+	defer server.Close()
+	client, err := trinogatewayclient.NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &BackendSetResource{client: client}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: backendSetId}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import failed: %v", resp.Diagnostics)
+	}
+
+	var data BackendSetResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := []BackendSetEntryModel{
+		{
+			Name:         types.StringValue("adhoc-1"),
+			ProxyTo:      types.StringValue("http://adhoc-1"),
+			Active:       types.BoolValue(false),
+			RoutingGroup: types.StringValue("adhoc"),
+			ExternalUrl:  types.StringNull(),
+			Weight:       types.Int64Null(),
+		},
+		{
+			Name:         types.StringValue("etl-1"),
+			ProxyTo:      types.StringValue("http://etl-1"),
+			Active:       types.BoolValue(true),
+			RoutingGroup: types.StringValue("etl"),
+			ExternalUrl:  types.StringValue("https://etl"),
+			Weight:       types.Int64Value(3),
+		},
+	}
+	if len(data.Backends) != len(want) {
+		t.Fatalf("got %d backends, want %d: %v", len(data.Backends), len(want), data.Backends)
+	}
+	for i := range want {
+		if data.Backends[i] != want[i] {
+			t.Fatalf("backend %d is %v, want %v", i, data.Backends[i], want[i])
+		}
+	}
+}
+
+func TestBackendSetEntryWeight(t *testing.T) {
+	zero, five := trinogatewayclient.Number(0), trinogatewayclient.Number(5)
+	tests := []struct {
+		name   string
+		prior  types.Int64
+		weight *trinogatewayclient.Number
+		want   types.Int64
+	}{
+		{name: "not reported", prior: types.Int64Null(), weight: nil, want: types.Int64Null()},
+		{name: "zero reported for unset", prior: types.Int64Null(), weight: &zero, want: types.Int64Null()},
+		{name: "explicit zero", prior: types.Int64Value(0), weight: &zero, want: types.Int64Value(0)},
+		{name: "set outside terraform", prior: types.Int64Null(), weight: &five, want: types.Int64Value(5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &trinogatewayclient.Backend{Name: "b", ProxyTo: "http://b", Weight: tt.weight}
+			entry := backendSetEntryFromDomain(BackendSetEntryModel{Weight: tt.prior}, backend)
+			if !entry.Weight.Equal(tt.want) {
+				t.Fatalf("got weight %v, want %v", entry.Weight, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendSetLeavesStandaloneBackends(t *testing.T) {
+	standalone := trinogatewayclient.Backend{Name: "standalone", ProxyTo: "http://standalone", ExternalUrl: "http://standalone", RoutingGroup: "adhoc", Active: true}
+	gateway, gatewayServer := newFakeGateway(t, standalone)
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gatewayServer.URL),
+	})
+	objectType := server.resourceType(t, "trinogateway_backend_set")
+	entryType := objectType.AttributeTypes["backends"].(tftypes.List).ElementType.(tftypes.Object)
+	backendSet := func(id tftypes.Value, names ...string) *tfprotov6.DynamicValue {
+		entries := make([]tftypes.Value, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, objectValue(entryType, map[string]tftypes.Value{
+				"name":          tftypes.NewValue(tftypes.String, name),
+				"proxy_to":      tftypes.NewValue(tftypes.String, "http://"+name),
+				"active":        tftypes.NewValue(tftypes.Bool, true),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+			}))
+		}
+		return dynamicValue(t, objectType, map[string]tftypes.Value{
+			"id":       id,
+			"backends": tftypes.NewValue(objectType.AttributeTypes["backends"], entries),
+		})
+	}
+	id := tftypes.NewValue(tftypes.String, backendSetId)
+	absent, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "trinogateway_backend_set",
+		PriorState:   &absent,
+		PlannedState: backendSet(tftypes.NewValue(tftypes.String, tftypes.UnknownValue), "adhoc-1", "adhoc-2"),
+		Config:       backendSet(tftypes.NewValue(tftypes.String, nil), "adhoc-1", "adhoc-2"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, resp.Diagnostics)
+	if got := gateway.Writes(); !slices.Equal(got, []string{"update adhoc-1", "update adhoc-2"}) {
+		t.Fatalf("create got writes %q, want only set backends updated", got)
+	}
+
+	resp, err = server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "trinogateway_backend_set",
+		PriorState:   backendSet(id, "adhoc-1", "adhoc-2"),
+		PlannedState: backendSet(id, "adhoc-1"),
+		Config:       backendSet(tftypes.NewValue(tftypes.String, nil), "adhoc-1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, resp.Diagnostics)
+	if got := gateway.Writes(); !slices.Equal(got, []string{"update adhoc-1", "update adhoc-2", "delete adhoc-2"}) {
+		t.Fatalf("update got writes %q, want only removed entry deleted", got)
+	}
+
+	readResp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "trinogateway_backend_set",
+		CurrentState: backendSet(id, "adhoc-1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, readResp.Diagnostics)
+	state, err := readResp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := state.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	var entries []tftypes.Value
+	if err := attributes["backends"].As(&entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d backends in state, want standalone backend not read into set", len(entries))
+	}
+}
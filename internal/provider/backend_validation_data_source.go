@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendValidationDataSource{}
+
+func NewBackendValidationDataSource() datasource.DataSource {
+	return &BackendValidationDataSource{}
+}
+
+// BackendValidationDataSource validates backend configuration without creating it.
+type BackendValidationDataSource struct{}
+
+// BackendValidationDataSourceModel describes the data source data model.
+type BackendValidationDataSourceModel struct {
+	Name         types.String   `tfsdk:"name"`
+	ProxyTo      types.String   `tfsdk:"proxy_to"`
+	Active       types.Bool     `tfsdk:"active"`
+	RoutingGroup types.String   `tfsdk:"routing_group"`
+	ExternalUrl  types.String   `tfsdk:"external_url"`
+	Weight       types.Int64    `tfsdk:"weight"`
+	Valid        types.Bool     `tfsdk:"valid"`
+	Errors       []types.String `tfsdk:"errors"`
+}
+
+func (d *BackendValidationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend_validation"
+}
+
+func (d *BackendValidationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Validates proposed backend configuration without creating it",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of backend",
+				Required:            true,
+			},
+			"proxy_to": schema.StringAttribute{
+				MarkdownDescription: "Backend url",
+				Required:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Backend activation",
+				Optional:            true,
+			},
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name",
+				Required:            true,
+			},
+			"external_url": schema.StringAttribute{
+				MarkdownDescription: "External backend url",
+				Optional:            true,
+			},
+			"weight": schema.Int64Attribute{
+				MarkdownDescription: "Weight for routing inside routing group. Not part of upstream Trino Gateway backend entity, for gateways with weighted routing. Sent only when set, explicit 0 is sent",
+				Optional:            true,
+			},
+			"valid": schema.BoolAttribute{
+				MarkdownDescription: "Whether configuration is valid",
+				Computed:            true,
+			},
+			"errors": schema.ListAttribute{
+				MarkdownDescription: "Validation errors",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BackendValidationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendValidationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backend := &trinogatewayclient.Backend{
+		Name:         data.Name.ValueString(),
+		ProxyTo:      data.ProxyTo.ValueString(),
+		RoutingGroup: data.RoutingGroup.ValueString(),
+		Active:       data.Active.ValueBool(),
+		ExternalUrl:  data.ExternalUrl.ValueString(),
+		Weight:       tfToWeight(data.Weight),
+	}
+	data.Errors = []types.String{}
+	for _, err := range backend.Validate() {
+		data.Errors = append(data.Errors, types.StringValue(err.Error()))
+	}
+	data.Valid = types.BoolValue(len(data.Errors) == 0)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
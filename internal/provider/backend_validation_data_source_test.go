@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestBackendValidationDataSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]tftypes.Value
+		wantErrors int
+	}{
+		{
+			name: "valid",
+			config: map[string]tftypes.Value{
+				"proxy_to":     tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"external_url": tftypes.NewValue(tftypes.String, "https://trino.example.com"),
+				"weight":       tftypes.NewValue(tftypes.Number, 0),
+			},
+		},
+		{
+			name: "invalid",
+			config: map[string]tftypes.Value{
+				"proxy_to":     tftypes.NewValue(tftypes.String, "ftp://trino-1"),
+				"external_url": tftypes.NewValue(tftypes.String, "https://"),
+				"weight":       tftypes.NewValue(tftypes.Number, -1),
+			},
+			wantErrors: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config["name"] = tftypes.NewValue(tftypes.String, "trino-1")
+			tt.config["routing_group"] = tftypes.NewValue(tftypes.String, "adhoc")
+			resp := readDataSource(t, NewBackendValidationDataSource(), &TrinoGatewayProviderData{}, tt.config)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var data BackendValidationDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if len(data.Errors) != tt.wantErrors || data.Valid.ValueBool() != (tt.wantErrors == 0) {
+				t.Fatalf("got valid %v with errors %v, want %d errors", data.Valid, data.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
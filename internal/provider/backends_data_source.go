@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+const (
+	backendStatusActive   = "active"
+	backendStatusInactive = "inactive"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendsDataSource{}
+
+func NewBackendsDataSource() datasource.DataSource {
+	return &BackendsDataSource{}
+}
+
+// BackendsDataSource defines the data source implementation.
+type BackendsDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendsDataSourceModel describes the data source data model.
+type BackendsDataSourceModel struct {
+	RoutingGroup  types.String              `tfsdk:"routing_group"`
+	RoutingGroups []types.String            `tfsdk:"routing_groups"`
+	Backends      []BackendsDataSourceEntry `tfsdk:"backends"`
+}
+
+type BackendsDataSourceEntry struct {
+	Name         types.String `tfsdk:"name"`
+	ProxyTo      types.String `tfsdk:"proxy_to"`
+	Active       types.Bool   `tfsdk:"active"`
+	Status       types.String `tfsdk:"status"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+	ExternalUrl  types.String `tfsdk:"external_url"`
+}
+
+func (d *BackendsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backends"
+}
+
+func (d *BackendsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List of backends registered in gateway",
+
+		Attributes: map[string]schema.Attribute{
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Return only backends of this routing group",
+				Optional:            true,
+			},
+			"routing_groups": schema.ListAttribute{
+				MarkdownDescription: "Return only backends of these routing groups. Conflicts with `routing_group`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("routing_group")),
+				},
+			},
+			"backends": schema.ListNestedAttribute{
+				MarkdownDescription: "Backends sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of backend",
+							Computed:            true,
+						},
+						"proxy_to": schema.StringAttribute{
+							MarkdownDescription: "Backend url",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Backend activation",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "`active` or `inactive`, derived from `active`",
+							Computed:            true,
+						},
+						"routing_group": schema.StringAttribute{
+							MarkdownDescription: "Routing group name",
+							Computed:            true,
+						},
+						"external_url": schema.StringAttribute{
+							MarkdownDescription: "External backend url",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BackendsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backends, err := d.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+
+	var routingGroups map[string]struct{}
+	if !data.RoutingGroup.IsNull() {
+		routingGroups = map[string]struct{}{data.RoutingGroup.ValueString(): {}}
+	}
+	if data.RoutingGroups != nil {
+		routingGroups = map[string]struct{}{}
+		for _, routingGroup := range data.RoutingGroups {
+			routingGroups[routingGroup.ValueString()] = struct{}{}
+		}
+	}
+
+	data.Backends = []BackendsDataSourceEntry{}
+	for _, backend := range sortedBackends(backends) {
+		if routingGroups != nil {
+			if _, ok := routingGroups[backend.RoutingGroup]; !ok {
+				continue
+			}
+		}
+		data.Backends = append(data.Backends, backendDomainToDataSourceEntry(backend))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func backendDomainToDataSourceEntry(domainmodel *trinogatewayclient.Backend) BackendsDataSourceEntry {
+	status := backendStatusInactive
+	if domainmodel.Active {
+		status = backendStatusActive
+	}
+	return BackendsDataSourceEntry{
+		Name:         types.StringValue(domainmodel.Name),
+		ProxyTo:      types.StringValue(domainmodel.ProxyTo),
+		Active:       types.BoolValue(domainmodel.Active),
+		Status:       types.StringValue(status),
+		RoutingGroup: types.StringValue(domainmodel.RoutingGroup),
+		ExternalUrl:  types.StringValue(domainmodel.ExternalUrl),
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testBackends = `[
+	{"name":"etl-1","proxyTo":"http://etl-1","routingGroup":"etl","active":false,"externalUrl":"http://etl-1"},
+	{"name":"adhoc-1","proxyTo":"http://adhoc-1","routingGroup":"adhoc","active":true,"externalUrl":"http://adhoc-1"}
+]`
+
+func TestBackendsDataSourceStatus(t *testing.T) {
+	client := backendsGateway(t, testBackends)
+	resp := readDataSource(t, NewBackendsDataSource(), &TrinoGatewayProviderData{Client: client}, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var data BackendsDataSourceModel
+	if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	statuses := map[string]string{}
+	for _, backend := range data.Backends {
+		statuses[backend.Name.ValueString()] = backend.Status.ValueString()
+	}
+	if statuses["adhoc-1"] != backendStatusActive || statuses["etl-1"] != backendStatusInactive {
+		t.Fatalf("got statuses %v", statuses)
+	}
+}
+
+func TestBackendsDataSourceRoutingGroups(t *testing.T) {
+	backends := `[
+		{"name":"reporting-1","routingGroup":"reporting","active":true},
+		{"name":"etl-1","routingGroup":"etl","active":true},
+		{"name":"adhoc-1","routingGroup":"adhoc","active":true}
+	]`
+	routingGroups := func(groups ...string) tftypes.Value {
+		values := []tftypes.Value{}
+		for _, group := range groups {
+			values = append(values, tftypes.NewValue(tftypes.String, group))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+	tests := []struct {
+		name   string
+		config map[string]tftypes.Value
+		want   []string
+	}{
+		{name: "all", want: []string{"adhoc-1", "etl-1", "reporting-1"}},
+		{
+			name:   "routing group",
+			config: map[string]tftypes.Value{"routing_group": tftypes.NewValue(tftypes.String, "etl")},
+			want:   []string{"etl-1"},
+		},
+		{
+			name:   "routing groups",
+			config: map[string]tftypes.Value{"routing_groups": routingGroups("reporting", "adhoc", "unknown")},
+			want:   []string{"adhoc-1", "reporting-1"},
+		},
+		{
+			name:   "empty routing groups",
+			config: map[string]tftypes.Value{"routing_groups": routingGroups()},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := readDataSource(t, NewBackendsDataSource(), &TrinoGatewayProviderData{Client: backendsGateway(t, backends)}, tt.config)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var data BackendsDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			names := []string{}
+			for _, backend := range data.Backends {
+				names = append(names, backend.Name.ValueString())
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Fatalf("got backends %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BackendsExportDataSource{}
+
+func NewBackendsExportDataSource() datasource.DataSource {
+	return &BackendsExportDataSource{}
+}
+
+// BackendsExportDataSource defines the data source implementation.
+type BackendsExportDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// BackendsExportDataSourceModel describes the data source data model.
+type BackendsExportDataSourceModel struct {
+	Json types.String `tfsdk:"json"`
+}
+
+func (d *BackendsExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backends_export"
+}
+
+func (d *BackendsExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "All gateway backends as json, for backups",
+
+		Attributes: map[string]schema.Attribute{
+			"json": schema.StringAttribute{
+				MarkdownDescription: "Indented json array of backends sorted by name",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *BackendsExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *BackendsExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BackendsExportDataSourceModel
+
+	export, err := d.client.ExportBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to export backends", err)
+		return
+	}
+	data.Json = types.StringValue(string(export))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// backendsSnapshotTTL bounds staleness of snapshot. Provider process lives for whole plan or apply,
+// so without it changes made outside terraform during long apply would never be seen.
+const backendsSnapshotTTL = 30 * time.Second
+
+// backendsSnapshot is backends list and default routing group shared by all reads of one terraform operation,
+// so refresh of N backends costs one call of each. Mutations made through provider invalidate it,
+// changes made outside terraform are seen after backendsSnapshotTTL at most.
+type backendsSnapshot struct {
+	mu       sync.Mutex
+	backends []*trinogatewayclient.Backend
+	// defaultRoutingGroup is nil until loaded
+	defaultRoutingGroup *string
+	backendsLoadedAt    time.Time
+	defaultLoadedAt     time.Time
+	ttl                 time.Duration
+	// now is injectable clock, time.Now if nil
+	now func() time.Time
+}
+
+func (s *backendsSnapshot) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func (s *backendsSnapshot) fresh(loadedAt time.Time) bool {
+	return s.clock().Sub(loadedAt) < s.ttl
+}
+
+// Get loads backends on first call and after ttl, concurrent callers wait for the same load.
+// Returned backends must not be modified.
+func (s *backendsSnapshot) Get(ctx context.Context, client trinogatewayclient.TrinoGatewayClient) ([]*trinogatewayclient.Backend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.backends != nil && s.fresh(s.backendsLoadedAt) {
+		return s.backends, nil
+	}
+	backends, err := client.GetAllBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.backends = backends
+	s.backendsLoadedAt = s.clock()
+	return backends, nil
+}
+
+// DefaultRoutingGroup loads default routing group on first call and after ttl, like Get.
+func (s *backendsSnapshot) DefaultRoutingGroup(ctx context.Context, client trinogatewayclient.TrinoGatewayClient) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.defaultRoutingGroup != nil && s.fresh(s.defaultLoadedAt) {
+		return *s.defaultRoutingGroup, nil
+	}
+	defaultRoutingGroup, err := client.GetDefaultRoutingGroup(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.defaultRoutingGroup = &defaultRoutingGroup
+	s.defaultLoadedAt = s.clock()
+	return defaultRoutingGroup, nil
+}
+
+func (s *backendsSnapshot) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backends = nil
+	s.defaultRoutingGroup = nil
+}
+
+// backendsSnapshots keeps snapshot per client. Clients with credentials overrides
+// may see different backends, so they must not share snapshot.
+type backendsSnapshots struct {
+	mu       sync.Mutex
+	byClient map[trinogatewayclient.TrinoGatewayClient]*backendsSnapshot
+	// ttl is backendsSnapshotTTL if zero
+	ttl time.Duration
+	// now is injectable clock, time.Now if nil
+	now func() time.Time
+}
+
+// Get returns backends from snapshot of client.
+func (s *backendsSnapshots) Get(ctx context.Context, client trinogatewayclient.TrinoGatewayClient) ([]*trinogatewayclient.Backend, error) {
+	return s.of(client).Get(ctx, client)
+}
+
+// DefaultRoutingGroup returns default routing group from snapshot of client.
+func (s *backendsSnapshots) DefaultRoutingGroup(ctx context.Context, client trinogatewayclient.TrinoGatewayClient) (string, error) {
+	return s.of(client).DefaultRoutingGroup(ctx, client)
+}
+
+func (s *backendsSnapshots) of(client trinogatewayclient.TrinoGatewayClient) *backendsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byClient == nil {
+		s.byClient = map[trinogatewayclient.TrinoGatewayClient]*backendsSnapshot{}
+	}
+	snapshot, ok := s.byClient[client]
+	if !ok {
+		ttl := s.ttl
+		if ttl == 0 {
+			ttl = backendsSnapshotTTL
+		}
+		snapshot = &backendsSnapshot{ttl: ttl, now: s.now}
+		s.byClient[client] = snapshot
+	}
+	return snapshot
+}
+
+// Invalidate invalidates snapshots of all clients, mutation made by one client is seen by all of them.
+func (s *backendsSnapshots) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, snapshot := range s.byClient {
+		snapshot.Invalidate()
+	}
+}
+
+// sortedBackends returns copy of backends sorted by name, gateway ordering is not stable.
+// Backends are not mutated, client may cache them.
+func sortedBackends(backends []*trinogatewayclient.Backend) []*trinogatewayclient.Backend {
+	sorted := slices.Clone(backends)
+	slices.SortFunc(sorted, func(a, b *trinogatewayclient.Backend) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// snapshotInvalidatingClient invalidates snapshots on every mutation.
+type snapshotInvalidatingClient struct {
+	trinogatewayclient.TrinoGatewayClient
+	snapshot *backendsSnapshots
+}
+
+func (c *snapshotInvalidatingClient) AddOrUpdateBackend(ctx context.Context, backend *trinogatewayclient.Backend) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.AddOrUpdateBackend(ctx, backend)
+}
+
+func (c *snapshotInvalidatingClient) UpdateBackendIfMatch(ctx context.Context, backend *trinogatewayclient.Backend, expectedVersion string) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.UpdateBackendIfMatch(ctx, backend, expectedVersion)
+}
+
+func (c *snapshotInvalidatingClient) DeactivateBackend(ctx context.Context, name string) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.DeactivateBackend(ctx, name)
+}
+
+func (c *snapshotInvalidatingClient) DeleteBackend(ctx context.Context, name string) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.DeleteBackend(ctx, name)
+}
+
+func (c *snapshotInvalidatingClient) DeleteBackends(ctx context.Context, names []string) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.DeleteBackends(ctx, names)
+}
+
+func (c *snapshotInvalidatingClient) ReplaceAllBackends(ctx context.Context, desired []*trinogatewayclient.Backend) (*trinogatewayclient.ReplaceSummary, error) {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.ReplaceAllBackends(ctx, desired)
+}
+
+func (c *snapshotInvalidatingClient) ReconcileBackends(ctx context.Context, desired []*trinogatewayclient.Backend, owned []string) (*trinogatewayclient.ReplaceSummary, error) {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.ReconcileBackends(ctx, desired, owned)
+}
+
+func (c *snapshotInvalidatingClient) SetDefaultRoutingGroup(ctx context.Context, routingGroup string) error {
+	defer c.snapshot.Invalidate()
+	return c.TrinoGatewayClient.SetDefaultRoutingGroup(ctx, routingGroup)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// countingClient counts gateway reads, mutations are not expected.
+type countingClient struct {
+	trinogatewayclient.TrinoGatewayClient
+
+	mu                  sync.Mutex
+	backends            []*trinogatewayclient.Backend
+	defaultRoutingGroup string
+	err                 error
+	listCalls           int
+	defaultCalls        int
+}
+
+func (c *countingClient) GetAllBackends(ctx context.Context) ([]*trinogatewayclient.Backend, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listCalls++
+	return c.backends, c.err
+}
+
+func (c *countingClient) GetDefaultRoutingGroup(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultCalls++
+	return c.defaultRoutingGroup, c.err
+}
+
+func TestBackendsSnapshotDefaultRoutingGroup(t *testing.T) {
+	client := &countingClient{defaultRoutingGroup: "adhoc"}
+	snapshots := &backendsSnapshots{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if group, err := snapshots.DefaultRoutingGroup(context.Background(), client); err != nil || group != "adhoc" {
+				t.Errorf("got %q, %v", group, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if client.defaultCalls != 1 {
+		t.Fatalf("default routing group fetched %d times, want 1", client.defaultCalls)
+	}
+
+	snapshots.Invalidate()
+	if _, err := snapshots.DefaultRoutingGroup(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	if client.defaultCalls != 2 {
+		t.Fatalf("default routing group fetched %d times after invalidation, want 2", client.defaultCalls)
+	}
+}
+
+func TestBackendsSnapshotDoesNotCacheErrors(t *testing.T) {
+	client := &countingClient{err: errors.New("gateway is down")}
+	snapshots := &backendsSnapshots{}
+	if _, err := snapshots.DefaultRoutingGroup(context.Background(), client); err == nil {
+		t.Fatal("want error")
+	}
+	client.err = nil
+	client.defaultRoutingGroup = "adhoc"
+	if group, err := snapshots.DefaultRoutingGroup(context.Background(), client); err != nil || group != "adhoc" {
+		t.Fatalf("got %q, %v after gateway recovered", group, err)
+	}
+}
+
+func TestBackendsSnapshotExpires(t *testing.T) {
+	client := &countingClient{backends: []*trinogatewayclient.Backend{{Name: "a"}}, defaultRoutingGroup: "adhoc"}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := &backendsSnapshots{ttl: time.Minute, now: func() time.Time { return now }}
+	load := func() {
+		if _, err := snapshots.Get(context.Background(), client); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := snapshots.DefaultRoutingGroup(context.Background(), client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	load()
+	now = now.Add(59 * time.Second)
+	load()
+	if client.listCalls != 1 || client.defaultCalls != 1 {
+		t.Fatalf("got %d list and %d default calls before ttl, want 1 and 1", client.listCalls, client.defaultCalls)
+	}
+	now = now.Add(time.Second)
+	load()
+	if client.listCalls != 2 || client.defaultCalls != 2 {
+		t.Fatalf("got %d list and %d default calls after ttl, want 2 and 2", client.listCalls, client.defaultCalls)
+	}
+}
+
+func TestBackendsSnapshotPerClient(t *testing.T) {
+	provider := &countingClient{backends: []*trinogatewayclient.Backend{{Name: "a"}, {Name: "b"}}}
+	// client with credentials override sees only backends of its account
+	override := &countingClient{backends: []*trinogatewayclient.Backend{{Name: "b"}}}
+	snapshots := &backendsSnapshots{}
+	for i := 0; i < 2; i++ {
+		if backends, err := snapshots.Get(context.Background(), provider); err != nil || len(backends) != 2 {
+			t.Fatalf("provider client got %d backends, %v", len(backends), err)
+		}
+		if backends, err := snapshots.Get(context.Background(), override); err != nil || len(backends) != 1 {
+			t.Fatalf("override client got %d backends, %v", len(backends), err)
+		}
+	}
+	if provider.listCalls != 1 || override.listCalls != 1 {
+		t.Fatalf("backends listed %d and %d times, want once per client", provider.listCalls, override.listCalls)
+	}
+}
+
+func TestSnapshotInvalidatingClient(t *testing.T) {
+	snapshots := &backendsSnapshots{}
+	provider := &countingClient{backends: []*trinogatewayclient.Backend{{Name: "a"}}}
+	override := &mutatingClient{countingClient: countingClient{backends: []*trinogatewayclient.Backend{{Name: "a"}}}}
+	wrapped := &snapshotInvalidatingClient{TrinoGatewayClient: override, snapshot: snapshots}
+	if _, err := snapshots.Get(context.Background(), provider); err != nil {
+		t.Fatal(err)
+	}
+	// mutation through override client is seen by provider client too
+	if err := wrapped.DeleteBackend(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snapshots.Get(context.Background(), provider); err != nil {
+		t.Fatal(err)
+	}
+	if provider.listCalls != 2 {
+		t.Fatalf("backends listed %d times, want 2", provider.listCalls)
+	}
+}
+
+// mutatingClient accepts deletes.
+type mutatingClient struct {
+	countingClient
+}
+
+func (c *mutatingClient) DeleteBackend(ctx context.Context, name string) error {
+	return nil
+}
+
+func TestSortedBackends(t *testing.T) {
+	gatewayOrder := []*trinogatewayclient.Backend{{Name: "trino-2"}, {Name: "etl-1"}, {Name: "trino-1"}}
+	var names []string
+	for _, backend := range sortedBackends(gatewayOrder) {
+		names = append(names, backend.Name)
+	}
+	if want := []string{"etl-1", "trino-1", "trino-2"}; !slices.Equal(names, want) {
+		t.Fatalf("got %q, want %q", names, want)
+	}
+	if gatewayOrder[0].Name != "trino-2" {
+		t.Fatal("gateway backends are reordered in place")
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// clientOverrides builds clients differing from provider client in few options.
+// Clients are reused, so resources with the same override share connections.
+type clientOverrides struct {
+	// newClient builds client with provider settings plus extra options
+	newClient func(opts ...trinogatewayclient.Option) (trinogatewayclient.TrinoGatewayClient, error)
+
+	mu      sync.Mutex
+	clients map[string]trinogatewayclient.TrinoGatewayClient
+}
+
+func (o *clientOverrides) get(key string, opts ...trinogatewayclient.Option) (trinogatewayclient.TrinoGatewayClient, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if client, ok := o.clients[key]; ok {
+		return client, nil
+	}
+	client, err := o.newClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if o.clients == nil {
+		o.clients = map[string]trinogatewayclient.TrinoGatewayClient{}
+	}
+	o.clients[key] = client
+	return client, nil
+}
+
+// credentialsOverride replaces provider credentials, either login and password or api key.
+type credentialsOverride struct {
+	auth   *trinogatewayclient.Auth
+	apiKey string
+}
+
+// key identifies credentials without keeping them readable in client cache keys.
+func (c *credentialsOverride) key() string {
+	var login, password string
+	if c.auth != nil {
+		login, password = c.auth.Login, c.auth.Password
+	}
+	sum := sha256.Sum256([]byte(login + "\x00" + password + "\x00" + c.apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// ClientWithOverrides returns provider client sending requests through another proxy and/or with another credentials.
+// Empty proxy url and nil credentials keep provider ones.
+func (p *TrinoGatewayProviderData) ClientWithOverrides(rawProxyUrl string, credentials *credentialsOverride) (trinogatewayclient.TrinoGatewayClient, error) {
+	var keys []string
+	var opts []trinogatewayclient.Option
+	if rawProxyUrl != "" {
+		proxyUrl, err := parseProxyUrl(rawProxyUrl)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, "proxy:"+rawProxyUrl)
+		opts = append(opts, trinogatewayclient.WithProxyUrl(proxyUrl))
+	}
+	if credentials != nil {
+		keys = append(keys, "auth:"+credentials.key())
+		opts = append(opts, trinogatewayclient.WithAuthOverride(credentials.auth, p.apiKeyHeader, credentials.apiKey))
+	}
+	if len(opts) == 0 {
+		return p.Client, nil
+	}
+	return p.clientOverrides.get(strings.Join(keys, " "), opts...)
+}
+
+func parseProxyUrl(rawProxyUrl string) (*url.URL, error) {
+	proxyUrl, err := url.Parse(rawProxyUrl)
+	if err != nil || proxyUrl.Host == "" {
+		return nil, fmt.Errorf("cant parse proxy url %q", rawProxyUrl)
+	}
+	return proxyUrl, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// overridableProviderData builds clients of endpoint, with overrides on top of them.
+func overridableProviderData(t *testing.T, endpoint string) *TrinoGatewayProviderData {
+	newClient := func(opts ...trinogatewayclient.Option) (trinogatewayclient.TrinoGatewayClient, error) {
+		return trinogatewayclient.NewTrinoGatewayClient(endpoint, nil, opts...)
+	}
+	client, err := newClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &TrinoGatewayProviderData{Client: client, clientOverrides: &clientOverrides{newClient: newClient}}
+}
+
+func TestClientWithProxyOverride(t *testing.T) {
+	providerData := overridableProviderData(t, "http://gateway")
+	withoutOverride, err := providerData.ClientWithOverrides("", nil)
+	if err != nil || withoutOverride != providerData.Client {
+		t.Fatalf("got %v, %v, want provider client", withoutOverride, err)
+	}
+	first, err := providerData.ClientWithOverrides("http://proxy-1:3128", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == providerData.Client {
+		t.Fatal("proxy override returned provider client")
+	}
+	again, err := providerData.ClientWithOverrides("http://proxy-1:3128", nil)
+	if err != nil || again != first {
+		t.Fatalf("got %v, %v, want reused client", again, err)
+	}
+	other, err := providerData.ClientWithOverrides("socks5://proxy-2:1080", nil)
+	if err != nil || other == first {
+		t.Fatalf("got %v, %v, want another client", other, err)
+	}
+	if _, err := providerData.ClientWithOverrides("proxy-3", nil); err == nil {
+		t.Fatal("want error for proxy url without host")
+	}
+}
+
+func TestClientWithCredentialsOverride(t *testing.T) {
+	var authorization, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		apiKey = r.Header.Get("X-Api-Key")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	providerData := overridableProviderData(t, server.URL)
+	providerData.apiKeyHeader = "X-Api-Key"
+
+	tests := []struct {
+		name              string
+		credentials       *credentialsOverride
+		wantAuthorization string
+		wantApiKey        string
+	}{
+		{name: "provider"},
+		{
+			name:              "basic auth",
+			credentials:       &credentialsOverride{auth: &trinogatewayclient.Auth{Login: "team", Password: "team-secret"}},
+			wantAuthorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("team:team-secret")),
+		},
+		{name: "api key", credentials: &credentialsOverride{apiKey: "team-key"}, wantApiKey: "team-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := providerData.ClientWithOverrides("", tt.credentials)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if authorization != tt.wantAuthorization || apiKey != tt.wantApiKey {
+				t.Fatalf("got Authorization %q and api key %q, want %q and %q", authorization, apiKey, tt.wantAuthorization, tt.wantApiKey)
+			}
+		})
+	}
+
+	first, err := providerData.ClientWithOverrides("", &credentialsOverride{apiKey: "team-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := providerData.ClientWithOverrides("", &credentialsOverride{apiKey: "team-key"})
+	if err != nil || again != first {
+		t.Fatalf("got %v, %v, want reused client", again, err)
+	}
+	for key := range providerData.clientOverrides.clients {
+		if strings.Contains(key, "team-key") || strings.Contains(key, "team-secret") {
+			t.Fatalf("client cache key %q contains secret", key)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerConfigFile is content of provider config_file, shared between workspaces.
+type providerConfigFile struct {
+	Endpoint     string `json:"endpoint"`
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	ApiKey       string `json:"api_key"`
+	ApiKeyHeader string `json:"api_key_header"`
+	TokenCommand string `json:"token_command"`
+	ProxyUrl     string `json:"proxy_url"`
+}
+
+func loadProviderConfigFile(path string) (*providerConfigFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cant read config file: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	config := &providerConfigFile{}
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("cant parse config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// mergeInto fills attributes not set explicitly in provider block.
+func (c *providerConfigFile) mergeInto(data *TrinoGatewayProviderModel) {
+	for _, field := range []struct {
+		attribute *types.String
+		value     string
+	}{
+		{&data.Endpoint, c.Endpoint},
+		{&data.Login, c.Login},
+		{&data.Password, c.Password},
+		{&data.ApiKey, c.ApiKey},
+		{&data.ApiKeyHeader, c.ApiKeyHeader},
+		{&data.TokenCommand, c.TokenCommand},
+		{&data.ProxyUrl, c.ProxyUrl},
+	} {
+		if field.attribute.IsNull() && field.value != "" {
+			*field.attribute = types.StringValue(field.value)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "trinogateway.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProviderConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: `{"endpoint":"https://gateway.example.com","login":"admin","password":"secret"}`},
+		{name: "unknown field", content: `{"endpoint":"https://gateway.example.com","tls_ca":"ca.pem"}`, wantErr: true},
+		{name: "not json", content: `endpoint = "https://gateway.example.com"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := loadProviderConfigFile(writeConfigFile(t, tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (config.Endpoint != "https://gateway.example.com" || config.Login != "admin" || config.Password != "secret") {
+				t.Fatalf("unexpected config: %+v", config)
+			}
+		})
+	}
+	if _, err := loadProviderConfigFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("want error for missing file")
+	}
+}
+
+func TestProviderConfigFileMergeInto(t *testing.T) {
+	config := &providerConfigFile{
+		Endpoint: "https://gateway.example.com",
+		Login:    "admin",
+		Password: "secret",
+	}
+	data := TrinoGatewayProviderModel{
+		Endpoint:     types.StringValue("https://other.example.com"),
+		Login:        types.StringNull(),
+		Password:     types.StringNull(),
+		ApiKey:       types.StringNull(),
+		ApiKeyHeader: types.StringNull(),
+		TokenCommand: types.StringNull(),
+		ProxyUrl:     types.StringNull(),
+	}
+	config.mergeInto(&data)
+	if data.Endpoint.ValueString() != "https://other.example.com" {
+		t.Fatalf("explicit endpoint is overridden by %s", data.Endpoint)
+	}
+	if data.Login.ValueString() != "admin" || data.Password.ValueString() != "secret" {
+		t.Fatalf("got login %s and password %s from file", data.Login, data.Password)
+	}
+	if !data.ApiKey.IsNull() || !data.ProxyUrl.IsNull() {
+		t.Fatalf("attributes missing in file are set: api_key %s, proxy_url %s", data.ApiKey, data.ProxyUrl)
+	}
+}
+
+func TestProviderEndpointFromConfigFile(t *testing.T) {
+	_, gatewayServer := newFakeGateway(t, trinogatewayclient.Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080", RoutingGroup: "adhoc", Active: true})
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"config_file": tftypes.NewValue(tftypes.String, writeConfigFile(t, `{"endpoint":"`+gatewayServer.URL+`"}`)),
+	})
+	objectType := server.resourceType(t, "trinogateway_backend")
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName: "trinogateway_backend",
+		CurrentState: dynamicValue(t, objectType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, "trino-1"),
+			"name": tftypes.NewValue(tftypes.String, "trino-1"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, resp.Diagnostics)
+	state, err := resp.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.IsNull() {
+		t.Fatal("backend is not read from gateway set in config file")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// backendsGateway serves backends list json on every request.
+func backendsGateway(t *testing.T, backends string) trinogatewayclient.TrinoGatewayClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(backends))
+	}))
+	t.Cleanup(server.Close)
+	client, err := trinogatewayclient.NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// readDataSource configures data source with providerData and reads it with config,
+// attributes missing in config are null.
+func readDataSource(t *testing.T, d datasource.DataSource, providerData *TrinoGatewayProviderData, config map[string]tftypes.Value) *datasource.ReadResponse {
+	ctx := context.Background()
+	if configurable, ok := d.(datasource.DataSourceWithConfigure); ok {
+		configureResp := &datasource.ConfigureResponse{}
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatal(configureResp.Diagnostics)
+		}
+	}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	d.Read(ctx, datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(objectType, config)},
+	}, resp)
+	return resp
+}
+
+// objectValue builds object of objectType from values, missing attributes are null.
+func objectValue(objectType tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		value, ok := values[name]
+		if !ok {
+			value = tftypes.NewValue(attributeType, nil)
+		}
+		attributes[name] = value
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+const (
+	// defaultRoutingGroupId is the only id of singleton resource
+	defaultRoutingGroupId = "default"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DefaultRoutingGroupResource{}
+var _ resource.ResourceWithImportState = &DefaultRoutingGroupResource{}
+
+func NewDefaultRoutingGroupResource() resource.Resource {
+	return &DefaultRoutingGroupResource{}
+}
+
+// DefaultRoutingGroupResource manages gateway default routing group.
+type DefaultRoutingGroupResource struct {
+	client  trinogatewayclient.TrinoGatewayClient
+	journal *trinogatewayclient.Journal
+
+	lowercaseRoutingGroups bool
+}
+
+// DefaultRoutingGroupResourceModel describes the resource data model.
+type DefaultRoutingGroupResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	RoutingGroup types.String `tfsdk:"routing_group"`
+}
+
+func (r *DefaultRoutingGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_routing_group"
+}
+
+func (r *DefaultRoutingGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gateway default routing group. There should be only one such resource per gateway. " +
+			"Destroying the resource leaves gateway default routing group as is",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Always `default`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name. It should have at least one backend",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *DefaultRoutingGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = providerData.Client
+	r.journal = providerData.Journal
+	r.lowercaseRoutingGroups = providerData.LowercaseRoutingGroups
+}
+
+func (r *DefaultRoutingGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data DefaultRoutingGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setDefaultRoutingGroup(ctx, data.RoutingGroup.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(defaultRoutingGroupId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultRoutingGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DefaultRoutingGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routingGroup, err := r.client.GetDefaultRoutingGroup(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get default routing group", err)
+		return
+	}
+	if routingGroup == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(defaultRoutingGroupId)
+	data.RoutingGroup = preserveRoutingGroup(r.lowercaseRoutingGroups, data.RoutingGroup, routingGroup)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultRoutingGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, reportSlowRequests := trackSlowRequests(ctx)
+	defer reportSlowRequests(&resp.Diagnostics)
+
+	var data DefaultRoutingGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setDefaultRoutingGroup(ctx, data.RoutingGroup.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultRoutingGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Gateway always has some default routing group, nothing to delete
+}
+
+func (r *DefaultRoutingGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != defaultRoutingGroupId {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			fmt.Sprintf("Default routing group can be imported only by id %q, got %q", defaultRoutingGroupId, req.ID),
+		)
+		return
+	}
+
+	routingGroup, err := r.client.GetDefaultRoutingGroup(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get default routing group", err)
+		return
+	}
+	if routingGroup == "" {
+		resp.Diagnostics.AddError("Default routing group not found", "Gateway does not report default routing group")
+		return
+	}
+
+	data := DefaultRoutingGroupResourceModel{
+		Id:           types.StringValue(defaultRoutingGroupId),
+		RoutingGroup: types.StringValue(routingGroup),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setDefaultRoutingGroup refuses routing groups without backends, queries would have nowhere to go.
+func (r *DefaultRoutingGroupResource) setDefaultRoutingGroup(ctx context.Context, routingGroup string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	routingGroup = normalizeRoutingGroup(r.lowercaseRoutingGroups, routingGroup)
+	backends, err := r.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list backends", err)
+		return diags
+	}
+	if _, ok := groupBackendsByRoutingGroup(backends)[routingGroup]; !ok {
+		diags.AddError(
+			"Routing group not found",
+			fmt.Sprintf("Routing group %q has no backends, register backend in it before making it default", routingGroup),
+		)
+		return diags
+	}
+	if err := r.client.SetDefaultRoutingGroup(ctx, routingGroup); err != nil {
+		addMutationError(&diags, r.journal, "Unable to set default routing group", err)
+	}
+	return diags
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestDefaultRoutingGroupCreate(t *testing.T) {
+	tests := []struct {
+		routingGroup string
+		wantErr      string
+		wantWrites   []string
+	}{
+		{routingGroup: "etl", wantWrites: []string{"set default etl"}},
+		{routingGroup: "reporting", wantErr: "Routing group not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.routingGroup, func(t *testing.T) {
+			gateway, server := newFakeGateway(t,
+				trinogatewayclient.Backend{Name: "adhoc-1", RoutingGroup: "adhoc", Active: true},
+				trinogatewayclient.Backend{Name: "etl-1", RoutingGroup: "etl", Active: true},
+			)
+			gateway.SetDefaultRoutingGroup("adhoc")
+			resp := create(t, NewDefaultRoutingGroupResource(), &TrinoGatewayProviderData{Client: newGatewayClient(t, server)}, map[string]tftypes.Value{
+				"routing_group": tftypes.NewValue(tftypes.String, tt.routingGroup),
+			})
+			if tt.wantErr == "" && resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if tt.wantErr != "" && (!resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.wantErr) {
+				t.Fatalf("got %v, want %q", resp.Diagnostics, tt.wantErr)
+			}
+			if writes := gateway.Writes(); !slices.Equal(writes, tt.wantWrites) {
+				t.Fatalf("got writes %v, want %v", writes, tt.wantWrites)
+			}
+		})
+	}
+}
+
+func TestDefaultRoutingGroupRead(t *testing.T) {
+	tests := []struct {
+		name                string
+		defaultRoutingGroup string
+		wantRemoved         bool
+	}{
+		{name: "reported", defaultRoutingGroup: "etl"},
+		{name: "not reported", wantRemoved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, server := newFakeGateway(t)
+			gateway.SetDefaultRoutingGroup(tt.defaultRoutingGroup)
+			resp := read(t, NewDefaultRoutingGroupResource(), &TrinoGatewayProviderData{Client: newGatewayClient(t, server)}, map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, defaultRoutingGroupId),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tt.wantRemoved {
+				t.Fatalf("got removed %v, want %v", resp.State.Raw.IsNull(), tt.wantRemoved)
+			}
+			if tt.wantRemoved {
+				return
+			}
+			var data DefaultRoutingGroupResourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.RoutingGroup.ValueString() != tt.defaultRoutingGroup {
+				t.Fatalf("got routing group %q, want %q", data.RoutingGroup.ValueString(), tt.defaultRoutingGroup)
+			}
+		})
+	}
+}
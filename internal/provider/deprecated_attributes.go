@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// deprecatedAttribute is resource attribute backed by gateway field deprecated since some gateway version.
+type deprecatedAttribute struct {
+	attribute string
+	since     string
+	message   string
+}
+
+// deprecatedBackendAttributes is empty while gateway has no deprecated backend fields.
+var deprecatedBackendAttributes = []deprecatedAttribute{}
+
+// gatewayVersionCache fetches gateway version once per provider run.
+type gatewayVersionCache struct {
+	mu      sync.Mutex
+	version string
+}
+
+func (c *gatewayVersionCache) get(ctx context.Context, client trinogatewayclient.TrinoGatewayClient) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.version != "" {
+		return c.version, nil
+	}
+	version, err := client.GetGatewayVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.version = version
+	return version, nil
+}
+
+// warnDeprecatedAttributes warns about configured attributes deprecated by gateway.
+// Gateway version is fetched only if some deprecated attribute is set.
+func warnDeprecatedAttributes(ctx context.Context, config tfsdk.Config, deprecated []deprecatedAttribute, gatewayVersion func() (string, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, entry := range deprecated {
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(entry.attribute), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		if value == nil || value.IsNull() {
+			continue
+		}
+		version, err := gatewayVersion()
+		if err != nil {
+			tflog.Warn(ctx, "cant get gateway version to check deprecated attributes", map[string]interface{}{"error": err.Error()})
+			return diags
+		}
+		cmp, err := compareGatewayVersions(version, entry.since)
+		if err != nil || cmp < 0 {
+			continue
+		}
+		diags.AddAttributeWarning(
+			path.Root(entry.attribute),
+			"Deprecated attribute",
+			fmt.Sprintf("%s is deprecated since gateway version %s, current version is %s. %s", entry.attribute, entry.since, version, entry.message),
+		)
+	}
+	return diags
+}
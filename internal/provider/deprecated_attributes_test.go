@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestWarnDeprecatedAttributes(t *testing.T) {
+	deprecated := []deprecatedAttribute{{attribute: "external_url", since: "14", message: "Use proxy_to."}}
+	schemaResp, objectType := configuredResource(t, &BackendResource{}, &TrinoGatewayProviderData{})
+	tests := []struct {
+		name         string
+		externalUrl  tftypes.Value
+		version      string
+		versionErr   error
+		wantWarning  bool
+		wantVersions int
+	}{
+		{name: "deprecated", externalUrl: tftypes.NewValue(tftypes.String, "https://trino.example.com"), version: "15", wantWarning: true, wantVersions: 1},
+		{name: "same version", externalUrl: tftypes.NewValue(tftypes.String, "https://trino.example.com"), version: "14", wantWarning: true, wantVersions: 1},
+		{name: "older gateway", externalUrl: tftypes.NewValue(tftypes.String, "https://trino.example.com"), version: "13", wantVersions: 1},
+		{name: "unparsable version", externalUrl: tftypes.NewValue(tftypes.String, "https://trino.example.com"), version: "latest", wantVersions: 1},
+		{name: "version error", externalUrl: tftypes.NewValue(tftypes.String, "https://trino.example.com"), versionErr: errors.New("unavailable"), wantVersions: 1},
+		{name: "not set", externalUrl: tftypes.NewValue(tftypes.String, nil), version: "15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(objectType, map[string]tftypes.Value{
+				"external_url": tt.externalUrl,
+			})}
+			versions := 0
+			diags := warnDeprecatedAttributes(context.Background(), config, deprecated, func() (string, error) {
+				versions++
+				return tt.version, tt.versionErr
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tt.wantWarning {
+				t.Fatalf("got warnings %v, want warning: %v", diags, tt.wantWarning)
+			}
+			if versions != tt.wantVersions {
+				t.Fatalf("gateway version fetched %d times, want %d", versions, tt.wantVersions)
+			}
+		})
+	}
+}
+
+func TestGatewayVersionCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"version":"14"}`))
+	}))
+	defer server.Close()
+	client, err := trinogatewayclient.NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := &gatewayVersionCache{}
+	for i := 0; i < 3; i++ {
+		version, err := cache.get(context.Background(), client)
+		if err != nil || version != "14" {
+			t.Fatalf("got %q, %v, want 14", version, err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("gateway version requested %d times, want 1", got)
+	}
+}
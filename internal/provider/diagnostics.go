@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Error codes are appended to client error diagnostics for tools parsing terraform output.
+// They are part of provider interface and must not be renamed.
+const (
+	errorCodeAuthFailed = "AUTH_FAILED"
+	errorCodeNotFound   = "NOT_FOUND"
+	errorCodeConflict   = "CONFLICT"
+	errorCodeTransient  = "TRANSIENT"
+	errorCodeReadOnly   = "READ_ONLY"
+	errorCodeGuard      = "GUARD_BLOCKED"
+	errorCodeUnknown    = "UNKNOWN"
+)
+
+// clientErrorCode classifies client error by http status or error type.
+func clientErrorCode(err error) string {
+	var authErr *trinogatewayclient.AuthError
+	var conflictErr *trinogatewayclient.ConflictError
+	var statusErr *trinogatewayclient.StatusError
+	var windowErr *trinogatewayclient.OutsideMaintenanceWindowError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &authErr):
+		return errorCodeAuthFailed
+	case errors.Is(err, trinogatewayclient.ErrReadOnly):
+		return errorCodeReadOnly
+	case errors.As(err, &windowErr):
+		return errorCodeGuard
+	case errors.Is(err, trinogatewayclient.ErrBackendNotFound):
+		return errorCodeNotFound
+	case errors.As(err, &conflictErr):
+		return errorCodeConflict
+	case errors.As(err, &statusErr):
+		return statusErrorCode(statusErr.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, trinogatewayclient.ErrCircuitOpen), errors.As(err, &netErr):
+		return errorCodeTransient
+	}
+	return errorCodeUnknown
+}
+
+func statusErrorCode(status int) string {
+	switch {
+	case status == http.StatusNotFound:
+		return errorCodeNotFound
+	case status == http.StatusConflict:
+		return errorCodeConflict
+	case status == http.StatusTooManyRequests || status >= 500:
+		return errorCodeTransient
+	}
+	return errorCodeUnknown
+}
+
+// addClientError reports failed client call, auth problems get their own summary.
+func addClientError(diags *diag.Diagnostics, message string, err error) {
+	code := clientErrorCode(err)
+	switch code {
+	case errorCodeAuthFailed:
+		diags.AddError("Authentication Error", fmt.Sprintf("%s: %s\n\nerror_code: %s", message, err, code))
+	case errorCodeReadOnly:
+		diags.AddError("Read-only mode", fmt.Sprintf("%s: %s. Unset provider `read_only` to apply changes\n\nerror_code: %s", message, err, code))
+	case errorCodeGuard:
+		diags.AddError("Outside maintenance window", fmt.Sprintf("%s: %s. Set provider `maintenance_window_force` to apply changes anyway\n\nerror_code: %s", message, err, code))
+	default:
+		diags.AddError("Client Error", fmt.Sprintf("%s, got error: %s\n\nerror_code: %s", message, err, code))
+	}
+}
+
+// addMutationError reports failed mutation together with mutations made before it during this run,
+// so user knows what was already changed on gateway.
+func addMutationError(diags *diag.Diagnostics, journal *trinogatewayclient.Journal, message string, err error) {
+	addClientError(diags, message, err)
+	if summary := journal.Summary(); summary != "" {
+		diags.AddWarning("Gateway changes made during this run", summary)
+	}
+}
+
+// addGuardError reports change refused by provider guard. It is always an error, so apply of the resource fails
+// instead of silently skipping the change. Terraform still applies independent resources, use -parallelism=1
+// or depends_on if they must not be applied after guard failure.
+func addGuardError(diags *diag.Diagnostics, guard string, detail string) {
+	diags.AddError(
+		"Blocked by provider guard",
+		fmt.Sprintf("%s. Nothing was changed on gateway by this operation, disable provider `%s` to proceed anyway\n\nerror_code: %s", detail, guard, errorCodeGuard),
+	)
+}
+
+// trackSlowRequests collects gateway requests slower than provider slow_request_threshold made with returned context.
+// Returned func reports them as warnings, it is meant to be deferred.
+func trackSlowRequests(ctx context.Context) (context.Context, func(diags *diag.Diagnostics)) {
+	ctx, slowRequests := trinogatewayclient.ContextWithSlowRequests(ctx)
+	return ctx, func(diags *diag.Diagnostics) {
+		for _, request := range slowRequests.Requests() {
+			diags.AddWarning(
+				"Slow gateway request",
+				fmt.Sprintf("%s %s took %s, which exceeds provider slow_request_threshold", request.Method, request.Path, request.Duration.Round(time.Millisecond)),
+			)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestClientErrorCodeByStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{status: http.StatusUnauthorized, want: errorCodeAuthFailed},
+		{status: http.StatusForbidden, want: errorCodeAuthFailed},
+		{status: http.StatusNotFound, want: errorCodeNotFound},
+		{status: http.StatusConflict, want: errorCodeConflict},
+		{status: http.StatusTooManyRequests, want: errorCodeTransient},
+		{status: http.StatusBadGateway, want: errorCodeTransient},
+		{status: http.StatusBadRequest, want: errorCodeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			_, err := newGatewayClient(t, server).GetAllBackends(context.Background())
+			if err == nil {
+				t.Fatal("want error")
+			}
+			if got := clientErrorCode(err); got != tt.want {
+				t.Fatalf("got %s, want %s for %v", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestClientErrorCode(t *testing.T) {
+	_, gatewayServer := newFakeGateway(t)
+	readOnlyClient, err := trinogatewayclient.NewTrinoGatewayClient(gatewayServer.URL, nil, trinogatewayclient.WithReadOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		err  func() error
+		want string
+	}{
+		{
+			name: "read only",
+			err:  func() error { return readOnlyClient.DeleteBackend(context.Background(), "trino-1") },
+			want: errorCodeReadOnly,
+		},
+		{
+			name: "backend not found",
+			err: func() error {
+				_, err := newGatewayClient(t, gatewayServer).GetBackend(context.Background(), "trino-1")
+				return err
+			},
+			want: errorCodeNotFound,
+		},
+		{
+			name: "version conflict",
+			err: func() error {
+				return fmt.Errorf("cant update: %w", &trinogatewayclient.ConflictError{Name: "trino-1"})
+			},
+			want: errorCodeConflict,
+		},
+		{
+			name: "connection refused",
+			err: func() error {
+				_, err := newGatewayClient(t, closedServer).GetAllBackends(context.Background())
+				return err
+			},
+			want: errorCodeTransient,
+		},
+		{
+			name: "deadline exceeded",
+			err: func() error {
+				_, err := newGatewayClient(t, gatewayServer).GetAllBackends(expired)
+				return err
+			},
+			want: errorCodeTransient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.err()
+			if err == nil {
+				t.Fatal("want error")
+			}
+			if got := clientErrorCode(err); got != tt.want {
+				t.Fatalf("got %s, want %s for %v", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestAddClientErrorCode(t *testing.T) {
+	var diags diag.Diagnostics
+	addClientError(&diags, "Unable to list backends", &trinogatewayclient.AuthError{StatusCode: http.StatusUnauthorized})
+	if len(diags) != 1 || diags[0].Summary() != "Authentication Error" {
+		t.Fatalf("got %v, want authentication error", diags)
+	}
+	if detail := diags[0].Detail(); !strings.HasSuffix(detail, "error_code: "+errorCodeAuthFailed) {
+		t.Fatalf("detail has no error code: %s", detail)
+	}
+}
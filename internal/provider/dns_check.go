@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hostResolver is implemented by *net.Resolver.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// checkUrlHostResolves warns if host of url cant be resolved.
+// Unknown and unparsable urls as well as ip addresses are not checked.
+func checkUrlHostResolves(ctx context.Context, resolver hostResolver, attribute path.Path, rawUrl types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if rawUrl.IsNull() || rawUrl.IsUnknown() {
+		return diags
+	}
+	parsed, err := url.Parse(rawUrl.ValueString())
+	if err != nil {
+		return diags
+	}
+	host := parsed.Hostname()
+	if host == "" || net.ParseIP(host) != nil {
+		return diags
+	}
+	if _, err := resolver.LookupHost(ctx, host); err != nil {
+		diags.AddAttributeWarning(
+			attribute,
+			"Host does not resolve",
+			fmt.Sprintf("Cant resolve host %q of %q, check it for typos: %s", host, rawUrl.ValueString(), err),
+		)
+	}
+	return diags
+}
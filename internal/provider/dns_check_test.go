@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// staticResolver resolves only listed hosts and records lookups.
+type staticResolver struct {
+	hosts   map[string]bool
+	lookups []string
+}
+
+func (r *staticResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups = append(r.lookups, host)
+	if !r.hosts[host] {
+		return nil, fmt.Errorf("no such host")
+	}
+	return []string{"10.0.0.1"}, nil
+}
+
+func TestCheckUrlHostResolves(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         types.String
+		wantWarning bool
+		wantLookup  bool
+	}{
+		{name: "resolvable", url: types.StringValue("http://trino-1:8080"), wantLookup: true},
+		{name: "unresolvable", url: types.StringValue("http://trino-typo:8080"), wantWarning: true, wantLookup: true},
+		{name: "ip address", url: types.StringValue("http://10.0.0.2:8080")},
+		{name: "unknown", url: types.StringUnknown()},
+		{name: "null", url: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &staticResolver{hosts: map[string]bool{"trino-1": true}}
+			diags := checkUrlHostResolves(context.Background(), resolver, path.Root("proxy_to"), tt.url)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got := diags.WarningsCount() == 1; got != tt.wantWarning {
+				t.Fatalf("got warnings %v, want warning: %v", diags, tt.wantWarning)
+			}
+			if got := len(resolver.lookups) == 1; got != tt.wantLookup {
+				t.Fatalf("got lookups %v, want lookup: %v", resolver.lookups, tt.wantLookup)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient/gatewaytest"
+)
+
+// newFakeGateway starts in-memory gateway with backends.
+func newFakeGateway(t *testing.T, backends ...trinogatewayclient.Backend) (*gatewaytest.Gateway, *httptest.Server) {
+	gateway, server := gatewaytest.New(t)
+	for _, backend := range backends {
+		gateway.Set(backend)
+	}
+	return gateway, server
+}
+
+// newGatewayClient is client of server without options.
+func newGatewayClient(t *testing.T, server *httptest.Server) trinogatewayclient.TrinoGatewayClient {
+	client, err := trinogatewayclient.NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// compareGatewayVersions compares dot separated numeric versions like "13" or "14.1".
+// Missing components are treated as zero.
+func compareGatewayVersions(a string, b string) (int, error) {
+	partsA, err := parseGatewayVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	partsB, err := parseGatewayVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		var partA, partB int
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		if partA != partB {
+			if partA < partB {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseGatewayVersion(version string) ([]int, error) {
+	// release builds may have suffix like "14-SNAPSHOT"
+	version, _, _ = strings.Cut(strings.TrimSpace(version), "-")
+	parts := []int{}
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway version %q", version)
+		}
+		parts = append(parts, number)
+	}
+	return parts, nil
+}
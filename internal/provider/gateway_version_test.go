@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestCompareGatewayVersions(t *testing.T) {
+	tests := []struct {
+		a       string
+		b       string
+		want    int
+		wantErr bool
+	}{
+		{a: "14", b: "13", want: 1},
+		{a: "14", b: "14.0", want: 0},
+		{a: "14.1", b: "14.2", want: -1},
+		{a: "14-SNAPSHOT", b: "14", want: 0},
+		{a: "9", b: "13", want: -1},
+		{a: "latest", b: "13", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got, err := compareGatewayVersions(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMinGatewayVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"14"}`))
+	}))
+	defer server.Close()
+	client, err := trinogatewayclient.NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		minVersion string
+		wantErr    bool
+	}{
+		{minVersion: "13"},
+		{minVersion: "14"},
+		{minVersion: "14.1", wantErr: true},
+		{minVersion: "15", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.minVersion, func(t *testing.T) {
+			diags := checkMinGatewayVersion(context.Background(), client, tt.minVersion)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
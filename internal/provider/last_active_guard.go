@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// routingGroupsLosingLastActive returns sorted routing groups having active backends in current,
+// but none in desired.
+func routingGroupsLosingLastActive(current []*trinogatewayclient.Backend, desired []*trinogatewayclient.Backend) []string {
+	stillActive := map[string]struct{}{}
+	for _, backend := range desired {
+		if backend.Active {
+			stillActive[backend.RoutingGroup] = struct{}{}
+		}
+	}
+	losing := map[string]struct{}{}
+	for _, backend := range current {
+		if _, ok := stillActive[backend.RoutingGroup]; backend.Active && !ok {
+			losing[backend.RoutingGroup] = struct{}{}
+		}
+	}
+	groups := make([]string, 0, len(losing))
+	for group := range losing {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// checkNoRoutingGroupLosesLastActive guards change of whole backends set, desired is built from current by caller.
+func checkNoRoutingGroupLosesLastActive(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, desired func(current []*trinogatewayclient.Backend) []*trinogatewayclient.Backend) diag.Diagnostics {
+	var diags diag.Diagnostics
+	current, err := client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&diags, "Unable to list backends", err)
+		return diags
+	}
+	groups := routingGroupsLosingLastActive(current, desired(current))
+	if len(groups) == 0 {
+		return diags
+	}
+	addGuardError(
+		&diags,
+		"prevent_last_active_delete",
+		fmt.Sprintf("Change would leave routing groups without active backends: %s", strings.Join(groups, ", ")),
+	)
+	return diags
+}
+
+// checkNotLastActiveMoved refuses moving the last active backend out of its routing group.
+func checkNotLastActiveMoved(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, name string, routingGroup string) diag.Diagnostics {
+	return checkNoRoutingGroupLosesLastActive(ctx, client, func(current []*trinogatewayclient.Backend) []*trinogatewayclient.Backend {
+		desired := make([]*trinogatewayclient.Backend, 0, len(current))
+		for _, backend := range current {
+			if backend.Name == name {
+				moved := *backend
+				moved.RoutingGroup = routingGroup
+				backend = &moved
+			}
+			desired = append(desired, backend)
+		}
+		return desired
+	})
+}
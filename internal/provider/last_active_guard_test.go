@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestRoutingGroupsLosingLastActive(t *testing.T) {
+	current := []*trinogatewayclient.Backend{
+		{Name: "adhoc-1", RoutingGroup: "adhoc", Active: true},
+		{Name: "etl-1", RoutingGroup: "etl", Active: true},
+		{Name: "reporting-1", RoutingGroup: "reporting", Active: true},
+		{Name: "batch-1", RoutingGroup: "batch", Active: false},
+	}
+	desired := []*trinogatewayclient.Backend{
+		{Name: "adhoc-2", RoutingGroup: "adhoc", Active: true},
+		{Name: "reporting-1", RoutingGroup: "reporting", Active: false},
+	}
+	got := routingGroupsLosingLastActive(current, desired)
+	if want := []string{"etl", "reporting"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestCheckNoRoutingGroupLosesLastActive(t *testing.T) {
+	client := backendsGateway(t, `[
+		{"name":"adhoc-1","routingGroup":"adhoc","active":true},
+		{"name":"etl-1","routingGroup":"etl","active":true}
+	]`)
+	tests := []struct {
+		name       string
+		deactivate string
+		wantErr    bool
+	}{
+		{name: "last active", deactivate: "etl-1", wantErr: true},
+		{name: "missing backend", deactivate: "etl-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := checkNoRoutingGroupLosesLastActive(context.Background(), client, func(current []*trinogatewayclient.Backend) []*trinogatewayclient.Backend {
+				desired := []*trinogatewayclient.Backend{}
+				for _, backend := range current {
+					backend := *backend
+					if backend.Name == tt.deactivate {
+						backend.Active = false
+					}
+					desired = append(desired, &backend)
+				}
+				return desired
+			})
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("got %v, want error: %v", diags, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			if summary := diags.Errors()[0].Summary(); summary != "Blocked by provider guard" {
+				t.Fatalf("got error %q, want guard error", summary)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "etl") || !strings.HasSuffix(detail, "error_code: "+errorCodeGuard) {
+				t.Fatalf("unexpected detail: %s", detail)
+			}
+		})
+	}
+}
@@ -6,15 +6,44 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
 )
 
+const (
+	defaultApiKeyHeader = "X-API-Key"
+	defaultDialTimeout  = 30 * time.Second
+	defaultKeepAlive    = 30 * time.Second
+
+	defaultCircuitBreakerWindow   = time.Minute
+	defaultCircuitBreakerCooldown = 30 * time.Second
+
+	externalUrlDefaultMirrorProxyTo = "mirror_proxy_to"
+	externalUrlDefaultNull          = "null"
+
+	importExternalUrlDefaulted = "defaulted"
+	importExternalUrlExplicit  = "explicit"
+
+	orphanCheckWarn  = "warn"
+	orphanCheckError = "error"
+)
+
 // Ensure TrinoGatewayProvider satisfies various provider interfaces.
 var _ provider.Provider = &TrinoGatewayProvider{}
 
@@ -26,11 +55,100 @@ type TrinoGatewayProvider struct {
 	version string
 }
 
+// TrinoGatewayProviderData is passed to resources and data sources.
+type TrinoGatewayProviderData struct {
+	Client trinogatewayclient.TrinoGatewayClient
+	// BackendsSnapshot is invalidated by every mutation made through Client or override clients,
+	// changes made outside terraform are seen after backendsSnapshotTTL at most
+	BackendsSnapshot *backendsSnapshots
+	clientOverrides  *clientOverrides
+	// apiKeyHeader is used by credentials overrides
+	apiKeyHeader string
+	// Journal records every mutation made by provider clients
+	Journal *trinogatewayclient.Journal
+
+	// DefaultRoutingGroup is empty if not configured
+	DefaultRoutingGroup string
+	UseGatewayDefaults  bool
+
+	WarnUrlSchemeMismatch bool
+	RecreateMissing       bool
+
+	PreventLastActiveDelete bool
+	LowercaseRoutingGroups  bool
+	// Resolver checks backend hosts during plan, nil if check is disabled
+	Resolver hostResolver
+	// ExternalUrlDefault is one of externalUrlDefault* constants
+	ExternalUrlDefault string
+	// ImportExternalUrl is one of importExternalUrl* constants
+	ImportExternalUrl string
+
+	DetectUpdateConflicts bool
+	RetryOnConflict       bool
+	UseGracefulDeactivate bool
+	ConfirmDelete         bool
+	ReadInFlightQueries   bool
+	// OrphanCheck is empty if check is disabled
+	OrphanCheck string
+
+	gatewayVersion *gatewayVersionCache
+}
+
 // TrinoGatewayProviderModel describes the provider data model.
 type TrinoGatewayProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Login    types.String `tfsdk:"login"`
-	Password types.String `tfsdk:"password"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Login          types.String `tfsdk:"login"`
+	Password       types.String `tfsdk:"password"`
+	ApiKey         types.String `tfsdk:"api_key"`
+	ApiKeyHeader   types.String `tfsdk:"api_key_header"`
+	TokenCommand   types.String `tfsdk:"token_command"`
+	TokenCachePath types.String `tfsdk:"token_cache_path"`
+
+	DefaultRoutingGroup types.String `tfsdk:"default_routing_group"`
+	UseGatewayDefaults  types.Bool   `tfsdk:"use_gateway_defaults"`
+
+	WarnUrlSchemeMismatch types.Bool   `tfsdk:"warn_url_scheme_mismatch"`
+	ProxyUrl              types.String `tfsdk:"proxy_url"`
+	DialTimeout           types.String `tfsdk:"dial_timeout"`
+	KeepAlive             types.String `tfsdk:"keep_alive"`
+	DeleteHttpMethod      types.String `tfsdk:"delete_http_method"`
+	DeletePath            types.String `tfsdk:"delete_path"`
+	ListPath              types.String `tfsdk:"list_path"`
+	RecreateMissing       types.Bool   `tfsdk:"recreate_missing"`
+	MinGatewayVersion     types.String `tfsdk:"min_gateway_version"`
+
+	PreventLastActiveDelete types.Bool        `tfsdk:"prevent_last_active_delete"`
+	StrictJson              types.Bool        `tfsdk:"strict_json"`
+	LowercaseRoutingGroups  types.Bool        `tfsdk:"lowercase_routing_groups"`
+	CheckProxyToDns         types.Bool        `tfsdk:"check_proxy_to_dns"`
+	Headers                 map[string]string `tfsdk:"headers"`
+	ExternalUrlDefault      types.String      `tfsdk:"external_url_default"`
+	ImportExternalUrl       types.String      `tfsdk:"import_external_url"`
+	MaxRetries              types.Int64       `tfsdk:"max_retries"`
+	RetryMaxElapsedTime     types.String      `tfsdk:"retry_max_elapsed_time"`
+	ReadOnly                types.Bool        `tfsdk:"read_only"`
+	DetectUpdateConflicts   types.Bool        `tfsdk:"detect_update_conflicts"`
+	RetryOnConflict         types.Bool        `tfsdk:"retry_on_conflict"`
+	UseGracefulDeactivate   types.Bool        `tfsdk:"use_graceful_deactivate"`
+	ConfirmDelete           types.Bool        `tfsdk:"confirm_delete"`
+	ReadInFlightQueries     types.Bool        `tfsdk:"read_in_flight_queries"`
+	OrphanCheck             types.String      `tfsdk:"orphan_check"`
+	ConfigFile              types.String      `tfsdk:"config_file"`
+	CheckCredentials        types.Bool        `tfsdk:"check_credentials"`
+	IdempotencyKeyHeader    types.String      `tfsdk:"idempotency_key_header"`
+	MaxResponseSize         types.Int64       `tfsdk:"max_response_size"`
+	RunIdHeader             types.String      `tfsdk:"run_id_header"`
+	CompressRequests        types.Bool        `tfsdk:"compress_requests"`
+	ContentType             types.String      `tfsdk:"content_type"`
+	SlowRequestThreshold    types.String      `tfsdk:"slow_request_threshold"`
+	CircuitBreakerThreshold types.Int64       `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerWindow    types.String      `tfsdk:"circuit_breaker_window"`
+	CircuitBreakerCooldown  types.String      `tfsdk:"circuit_breaker_cooldown"`
+	MaintenanceWindow       types.String      `tfsdk:"maintenance_window"`
+	MaintenanceWindowTz     types.String      `tfsdk:"maintenance_window_timezone"`
+	MaintenanceWindowForce  types.Bool        `tfsdk:"maintenance_window_force"`
+	StreamBackendsList      types.Bool        `tfsdk:"stream_backends_list"`
+	OmitEmptyFields         types.Bool        `tfsdk:"omit_empty_fields"`
 }
 
 func (p *TrinoGatewayProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -40,10 +158,20 @@ func (p *TrinoGatewayProvider) Metadata(ctx context.Context, req provider.Metada
 
 func (p *TrinoGatewayProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provider configuration, including `password`, `api_key`, `token_command` and `headers`, " +
+			"is never stored in plan or state, so credentials set here need no write-only attributes. " +
+			"Backend `auth` credentials are resource attributes and are stored in state, marked sensitive. " +
+			"Backends list read by `trinogateway_backend` is shared by resources for up to 30 seconds, " +
+			"so changes made outside terraform during plan or apply may be seen with that delay",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "Trino gateway endpoint",
-				Required:            true,
+				MarkdownDescription: "Trino gateway endpoint. Required unless set in `config_file`. Endpoint without scheme gets `https://` with a warning",
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to json file with `endpoint`, `login`, `password`, `api_key`, `api_key_header`, `token_command` and `proxy_url`. " +
+					"Attributes set in provider block take precedence",
+				Optional: true,
 			},
 			"login": schema.StringAttribute{
 				MarkdownDescription: "login",
@@ -55,6 +183,256 @@ func (p *TrinoGatewayProvider) Schema(ctx context.Context, req provider.SchemaRe
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "Static API key sent with every request. Conflicts with `login`/`password`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_key_header": schema.StringAttribute{
+				MarkdownDescription: "Header used to send `api_key`. Default `X-API-Key`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"token_command": schema.StringAttribute{
+				MarkdownDescription: "Shell command printing bearer token to stdout. It is executed again when gateway responds 401. Conflicts with `login`/`password` and `api_key`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"token_cache_path": schema.StringAttribute{
+				MarkdownDescription: "Directory to cache `token_command` tokens in, so many short terraform runs reuse one token. " +
+					"Files are not encrypted, they are created with 0600 permissions and ignored if permissions are wider. " +
+					"Jwt tokens are reused until their `exp`, others until gateway responds 401. Disabled by default",
+				Optional: true,
+			},
+			"confirm_delete": schema.BoolAttribute{
+				MarkdownDescription: "After deleting backend wait up to 1m until gateway stops listing it, for gateways deleting asynchronously",
+				Optional:            true,
+			},
+			"read_in_flight_queries": schema.BoolAttribute{
+				MarkdownDescription: "Fill backend `in_flight_queries`. Costs one gateway request per backend on every read, enable while draining backends",
+				Optional:            true,
+			},
+			"default_routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group for backends without explicit `routing_group`",
+				Optional:            true,
+			},
+			"use_gateway_defaults": schema.BoolAttribute{
+				MarkdownDescription: "Fill unset `routing_group` and `external_url` of new backends from gateway backend defaults",
+				Optional:            true,
+			},
+			"warn_url_scheme_mismatch": schema.BoolAttribute{
+				MarkdownDescription: "Warn when backend `external_url` and `proxy_to` use different schemes (http/https)",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Fail every gateway change, reads and data sources keep working. Guardrail for plans against protected gateways",
+				Optional:            true,
+			},
+			"recreate_missing": schema.BoolAttribute{
+				MarkdownDescription: "Plan replacement of backends deleted outside of terraform instead of dropping them from state",
+				Optional:            true,
+			},
+			"min_gateway_version": schema.StringAttribute{
+				MarkdownDescription: "Fail if gateway version is lower, e.g. `13`",
+				Optional:            true,
+			},
+			"prevent_last_active_delete": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete, deactivate or move out the last active backend of a routing group, " +
+					"also when backend set or membership resources would do it. Refusal is an error with `GUARD_BLOCKED` error code",
+				Optional: true,
+			},
+			"lowercase_routing_groups": schema.BoolAttribute{
+				MarkdownDescription: "Send routing groups to gateway in lower case, so differently cased names dont create duplicate groups. " +
+					"State keeps configured casing. Groups with upper case letters created outside of terraform cant be targeted",
+				Optional: true,
+			},
+			"check_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Verify credentials with read-only request when provider is configured, to fail fast on wrong auth",
+				Optional:            true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed requests within `circuit_breaker_window` after which requests fail fast " +
+					"without reaching gateway for `circuit_breaker_cooldown`. Failures are network errors and 429/502/503/504 responses, " +
+					"each retry counts. Speeds up failing runs during gateway outage. Disabled by default",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"circuit_breaker_window": schema.StringAttribute{
+				MarkdownDescription: "Window of failures counted by circuit breaker, e.g. `1m`. Default `1m`",
+				Optional:            true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "How long open circuit breaker fails requests fast, e.g. `30s`. After it requests are let through " +
+					"and first failure opens breaker again. Default `30s`",
+				Optional: true,
+			},
+			"maintenance_window": schema.StringAttribute{
+				MarkdownDescription: "Daily window when gateway changes are allowed, `HH:MM-HH:MM`, e.g. `22:00-06:00`. " +
+					"Outside of it every create, update and delete fails, plan and refresh keep working. Disabled by default",
+				Optional: true,
+			},
+			"maintenance_window_timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone of `maintenance_window`, e.g. `Europe/Berlin`. Default `UTC`",
+				Optional:            true,
+			},
+			"maintenance_window_force": schema.BoolAttribute{
+				MarkdownDescription: "Allow gateway changes outside of `maintenance_window`, for emergency fixes",
+				Optional:            true,
+			},
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "Encoding of backend entities: `json` or `yaml` for gateway forks speaking yaml. Other requests are always json. Default `json`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(trinogatewayclient.ContentTypeJSON, trinogatewayclient.ContentTypeYAML),
+				},
+			},
+			"slow_request_threshold": schema.StringAttribute{
+				MarkdownDescription: "Warn when gateway request made by resource create, update or delete takes longer, e.g. `5s`. " +
+					"Warning names request method, path and duration. Disabled by default",
+				Optional: true,
+			},
+			"omit_empty_fields": schema.BoolAttribute{
+				MarkdownDescription: "Omit empty optional fields, like `externalUrl`, from backends sent to gateway instead of sending empty strings. " +
+					"For gateways treating absent field differently from empty one. Unset weight is never sent",
+				Optional: true,
+			},
+			"stream_backends_list": schema.BoolAttribute{
+				MarkdownDescription: "Ask gateway for backends list as json lines (`application/x-ndjson`) and decode it line by line, " +
+					"for gateways with very large backend sets. Gateway answering with json array is handled as usual. Ignored with `content_type = \"yaml\"`",
+				Optional: true,
+			},
+			"compress_requests": schema.BoolAttribute{
+				MarkdownDescription: "Gzip request bodies and send them with `Content-Encoding: gzip`, reduces traffic of big backend sets. " +
+					"Gateway does not advertise support for it, enable only if gateway or proxy in front of it decodes such requests",
+				Optional: true,
+			},
+			"check_proxy_to_dns": schema.BoolAttribute{
+				MarkdownDescription: "Warn during plan when host of backend `proxy_to` does not resolve. Requires DNS access from where plan runs",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Headers sent with every request. They override default ones, e.g. `Accept: application/json` of GET requests. " +
+					"Their values are redacted in TRACE logs",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"external_url_default": schema.StringAttribute{
+				MarkdownDescription: "What backend `external_url` becomes if it is not set: `mirror_proxy_to` copies `proxy_to`, " +
+					"`null` leaves it empty on gateway and null in state. Default `mirror_proxy_to`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(externalUrlDefaultMirrorProxyTo, externalUrlDefaultNull),
+				},
+			},
+			"import_external_url": schema.StringAttribute{
+				MarkdownDescription: "How imported backend `external_url` equal to `proxy_to` is treated: `defaulted` treats it as not set, " +
+					"so it is null in state with `external_url_default = \"null\"`, `explicit` always keeps gateway value in state. " +
+					"Use `explicit` if imported resources set `external_url`, otherwise `defaulted`. " +
+					"With `external_url_default = \"mirror_proxy_to\"` both give the same state. Default `defaulted`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(importExternalUrlDefaulted, importExternalUrlExplicit),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Retries of requests failed by network errors or 429/502/503/504 responses. Default 0",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_response_size": schema.Int64Attribute{
+				MarkdownDescription: "Max size of backends list response in bytes, protects provider from misbehaving gateway. Default 64MiB",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"run_id_header": schema.StringAttribute{
+				MarkdownDescription: "Header of run id sent with every request, so gateway logs can be correlated with terraform runs. " +
+					"Id is random, the same for all requests of one terraform run and is logged on provider configuration. " +
+					"Terraform does not pass its operation ids to providers, so requests of different resources are not told apart. Not sent by default",
+				Optional: true,
+			},
+			"idempotency_key_header": schema.StringAttribute{
+				MarkdownDescription: "Header of idempotency key sent with backend upserts when `max_retries` is set, the key is the same for all attempts. Default `Idempotency-Key`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("max_retries")),
+				},
+			},
+			"retry_max_elapsed_time": schema.StringAttribute{
+				MarkdownDescription: "Time budget of all attempts of single request, e.g. `1m`. No retry is started after it is exhausted, last error is returned. " +
+					"Requires `max_retries`. Default unlimited",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("max_retries")),
+				},
+			},
+			"detect_update_conflicts": schema.BoolAttribute{
+				MarkdownDescription: "Fail backend update if backend was changed on gateway after terraform read it, instead of overwriting the change. " +
+					"Write is conditional (`If-Match`) only on gateways sending backends list `ETag`, otherwise a change made right before the write is still overwritten",
+				Optional: true,
+			},
+			"retry_on_conflict": schema.BoolAttribute{
+				MarkdownDescription: "With `detect_update_conflicts` retry conflicting update against refreshed backend instead of failing",
+				Optional:            true,
+			},
+			"use_graceful_deactivate": schema.BoolAttribute{
+				MarkdownDescription: "Deactivate backends through gateway deactivate endpoint, which may drain them gracefully, " +
+					"when `active = false` is the only change. Other changes still replace whole backend",
+				Optional: true,
+			},
+			"orphan_check": schema.StringAttribute{
+				MarkdownDescription: "What to do when backend delete leaves gateway default routing group without backends: `warn` or `error`. Disabled by default",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(orphanCheckWarn, orphanCheckError),
+				},
+			},
+			"strict_json": schema.BoolAttribute{
+				MarkdownDescription: "Fail on unknown fields in gateway responses, to detect schema drift between gateway and provider",
+				Optional:            true,
+			},
+			"dial_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of establishing tcp connection to gateway, e.g. `10s`. Default `30s`",
+				Optional:            true,
+			},
+			"keep_alive": schema.StringAttribute{
+				MarkdownDescription: "Keep-alive period of tcp connections to gateway, e.g. `15s`. Default `30s`",
+				Optional:            true,
+			},
+			"delete_http_method": schema.StringAttribute{
+				MarkdownDescription: "Http method of delete backend request: `POST` or `DELETE`. Default `POST`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodPost, http.MethodDelete),
+				},
+			},
+			"delete_path": schema.StringAttribute{
+				MarkdownDescription: "Path of delete backend request. Default `/gateway/backend/modify/delete`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must start with /"),
+				},
+			},
+			"list_path": schema.StringAttribute{
+				MarkdownDescription: "Path of backends list request, it is also used by `check_credentials`. Default `/entity/GATEWAY_BACKEND`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must start with /"),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy for gateway requests. `socks5://` and `socks5h://` urls use SOCKS5, others are treated as http proxy",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -68,6 +446,15 @@ func (p *TrinoGatewayProvider) Configure(ctx context.Context, req provider.Confi
 		return
 	}
 
+	if !data.ConfigFile.IsNull() {
+		configFile, err := loadProviderConfigFile(data.ConfigFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("config_file"), "Invalid provider config file", err.Error())
+			return
+		}
+		configFile.mergeInto(&data)
+	}
+
 	if data.Endpoint.IsNull() {
 		resp.Diagnostics.AddError(
 			"Endpoint for trino gateway client is not specify",
@@ -75,6 +462,14 @@ func (p *TrinoGatewayProvider) Configure(ctx context.Context, req provider.Confi
 		)
 		return
 	}
+	if endpoint, inferred := inferEndpointScheme(data.Endpoint.ValueString()); inferred {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("endpoint"),
+			"Endpoint without scheme",
+			fmt.Sprintf("Endpoint %q has no scheme, %q is used. Set scheme explicitly, e.g. `http://` for plain http gateway", data.Endpoint.ValueString(), endpoint),
+		)
+		data.Endpoint = types.StringValue(endpoint)
+	}
 
 	var auth *trinogatewayclient.Auth
 	if !data.Login.IsNull() {
@@ -90,10 +485,144 @@ func (p *TrinoGatewayProvider) Configure(ctx context.Context, req provider.Confi
 			Password: data.Password.ValueString(),
 		}
 	}
+	journal := &trinogatewayclient.Journal{}
+	opts := []trinogatewayclient.Option{trinogatewayclient.WithRequestObserver(journal)}
+	apiKeyHeader := defaultApiKeyHeader
+	if !data.ApiKeyHeader.IsNull() {
+		apiKeyHeader = data.ApiKeyHeader.ValueString()
+	}
+	if !data.ApiKey.IsNull() {
+		if auth != nil {
+			resp.Diagnostics.AddError(
+				"Cant configure trino gateway client auth",
+				"Cant configure trino gateway client auth: api_key and login/password are mutually exclusive",
+			)
+			return
+		}
+		opts = append(opts, trinogatewayclient.WithAPIKey(apiKeyHeader, data.ApiKey.ValueString()))
+	}
+	if !data.TokenCommand.IsNull() {
+		if auth != nil || !data.ApiKey.IsNull() {
+			resp.Diagnostics.AddError(
+				"Cant configure trino gateway client auth",
+				"Cant configure trino gateway client auth: token_command, api_key and login/password are mutually exclusive",
+			)
+			return
+		}
+		tokenSource := trinogatewayclient.CommandTokenSource(data.TokenCommand.ValueString())
+		if !data.TokenCachePath.IsNull() {
+			tokenSource = trinogatewayclient.DiskCachedTokenSource(
+				data.TokenCachePath.ValueString(),
+				data.Endpoint.ValueString()+"\x00"+data.TokenCommand.ValueString(),
+				tokenSource,
+			)
+		}
+		opts = append(opts, trinogatewayclient.WithBearerTokenSource(tokenSource))
+	}
+	if data.ReadOnly.ValueBool() {
+		opts = append(opts, trinogatewayclient.WithReadOnly())
+	}
+	if !data.MaintenanceWindow.IsNull() {
+		location := time.UTC
+		if !data.MaintenanceWindowTz.IsNull() {
+			var err error
+			location, err = time.LoadLocation(data.MaintenanceWindowTz.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("maintenance_window_timezone"), "Cant parse maintenance_window_timezone", err.Error())
+				return
+			}
+		}
+		window, err := trinogatewayclient.ParseMaintenanceWindow(data.MaintenanceWindow.ValueString(), location)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("maintenance_window"), "Cant parse maintenance_window", err.Error())
+			return
+		}
+		if data.MaintenanceWindowForce.ValueBool() {
+			tflog.Warn(ctx, "maintenance_window_force is set, gateway changes are allowed outside of maintenance window", map[string]interface{}{"maintenance_window": window.String()})
+		} else {
+			opts = append(opts, trinogatewayclient.WithMaintenanceWindow(window, nil))
+		}
+	}
+	if data.StrictJson.ValueBool() {
+		opts = append(opts, trinogatewayclient.WithStrictJSON())
+	}
+	if !data.SlowRequestThreshold.IsNull() {
+		slowRequestThreshold := parseDuration(&resp.Diagnostics, "slow_request_threshold", data.SlowRequestThreshold, 0)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		opts = append(opts, trinogatewayclient.WithSlowRequestThreshold(slowRequestThreshold))
+	}
+	if !data.ContentType.IsNull() {
+		opts = append(opts, trinogatewayclient.WithContentType(data.ContentType.ValueString()))
+	}
+	if data.OmitEmptyFields.ValueBool() {
+		opts = append(opts, trinogatewayclient.WithOmitEmptyFields())
+	}
+	if data.StreamBackendsList.ValueBool() {
+		opts = append(opts, trinogatewayclient.WithStreamingList())
+	}
+	if data.CompressRequests.ValueBool() {
+		opts = append(opts, trinogatewayclient.WithRequestCompression())
+	}
+	if len(data.Headers) > 0 {
+		opts = append(opts, trinogatewayclient.WithHeaders(data.Headers))
+	}
+	if !data.DialTimeout.IsNull() || !data.KeepAlive.IsNull() {
+		dialTimeout := parseDuration(&resp.Diagnostics, "dial_timeout", data.DialTimeout, defaultDialTimeout)
+		keepAlive := parseDuration(&resp.Diagnostics, "keep_alive", data.KeepAlive, defaultKeepAlive)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		opts = append(opts, trinogatewayclient.WithDialer(dialTimeout, keepAlive))
+	}
+	// parsed even without retries, so invalid budget is reported
+	retryMaxElapsedTime := parseDuration(&resp.Diagnostics, "retry_max_elapsed_time", data.RetryMaxElapsedTime, 0)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.MaxRetries.ValueInt64() > 0 {
+		opts = append(opts, trinogatewayclient.WithRetry(int(data.MaxRetries.ValueInt64()), retryMaxElapsedTime))
+		if !data.IdempotencyKeyHeader.IsNull() {
+			opts = append(opts, trinogatewayclient.WithIdempotencyKeyHeader(data.IdempotencyKeyHeader.ValueString()))
+		}
+	}
+	if !data.CircuitBreakerThreshold.IsNull() {
+		window := parseDuration(&resp.Diagnostics, "circuit_breaker_window", data.CircuitBreakerWindow, defaultCircuitBreakerWindow)
+		cooldown := parseDuration(&resp.Diagnostics, "circuit_breaker_cooldown", data.CircuitBreakerCooldown, defaultCircuitBreakerCooldown)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		opts = append(opts, trinogatewayclient.WithCircuitBreaker(int(data.CircuitBreakerThreshold.ValueInt64()), window, cooldown))
+	}
+	if !data.MaxResponseSize.IsNull() {
+		opts = append(opts, trinogatewayclient.WithMaxResponseSize(data.MaxResponseSize.ValueInt64()))
+	}
+	if !data.RunIdHeader.IsNull() {
+		opts = append(opts, trinogatewayclient.WithRunIdHeader(data.RunIdHeader.ValueString()))
+	}
+	if !data.DeletePath.IsNull() {
+		opts = append(opts, trinogatewayclient.WithDeletePath(data.DeletePath.ValueString()))
+	}
+	if !data.ListPath.IsNull() {
+		opts = append(opts, trinogatewayclient.WithListPath(data.ListPath.ValueString()))
+	}
+	if !data.DeleteHttpMethod.IsNull() {
+		opts = append(opts, trinogatewayclient.WithDeleteMethod(data.DeleteHttpMethod.ValueString()))
+	}
+	if !data.ProxyUrl.IsNull() {
+		proxyUrl, err := parseProxyUrl(data.ProxyUrl.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid proxy url", err.Error())
+			return
+		}
+		opts = append(opts, trinogatewayclient.WithProxyUrl(proxyUrl))
+	}
 	// Example client configuration for data sources and resources
 	client, err := trinogatewayclient.NewTrinoGatewayClient(
 		data.Endpoint.ValueString(),
 		auth,
+		opts...,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -102,18 +631,135 @@ func (p *TrinoGatewayProvider) Configure(ctx context.Context, req provider.Confi
 		)
 		return
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	if !data.RunIdHeader.IsNull() {
+		tflog.Info(ctx, "gateway requests are sent with run id", map[string]interface{}{"header": data.RunIdHeader.ValueString(), "run_id": client.RunId()})
+	}
+	if data.CheckCredentials.ValueBool() {
+		if err := client.Authenticate(ctx); err != nil {
+			addClientError(&resp.Diagnostics, "Unable to authenticate to trino gateway", err)
+			return
+		}
+	}
+	if !data.MinGatewayVersion.IsNull() {
+		resp.Diagnostics.Append(checkMinGatewayVersion(ctx, client, data.MinGatewayVersion.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	snapshot := &backendsSnapshots{}
+	providerData := &TrinoGatewayProviderData{
+		Client:           &snapshotInvalidatingClient{TrinoGatewayClient: client, snapshot: snapshot},
+		BackendsSnapshot: snapshot,
+		Journal:          journal,
+		apiKeyHeader:     apiKeyHeader,
+		clientOverrides: &clientOverrides{
+			newClient: func(extraOpts ...trinogatewayclient.Option) (trinogatewayclient.TrinoGatewayClient, error) {
+				client, err := trinogatewayclient.NewTrinoGatewayClient(
+					data.Endpoint.ValueString(),
+					auth,
+					append(append(slices.Clone(opts), trinogatewayclient.WithRunId(client.RunId())), extraOpts...)...,
+				)
+				if err != nil {
+					return nil, err
+				}
+				return &snapshotInvalidatingClient{TrinoGatewayClient: client, snapshot: snapshot}, nil
+			},
+		},
+		DefaultRoutingGroup: data.DefaultRoutingGroup.ValueString(),
+		UseGatewayDefaults:  data.UseGatewayDefaults.ValueBool(),
+
+		WarnUrlSchemeMismatch: data.WarnUrlSchemeMismatch.ValueBool(),
+		RecreateMissing:       data.RecreateMissing.ValueBool(),
+
+		PreventLastActiveDelete: data.PreventLastActiveDelete.ValueBool(),
+		LowercaseRoutingGroups:  data.LowercaseRoutingGroups.ValueBool(),
+		ExternalUrlDefault:      externalUrlDefaultMirrorProxyTo,
+		ImportExternalUrl:       importExternalUrlDefaulted,
+		DetectUpdateConflicts:   data.DetectUpdateConflicts.ValueBool(),
+		RetryOnConflict:         data.RetryOnConflict.ValueBool(),
+		UseGracefulDeactivate:   data.UseGracefulDeactivate.ValueBool(),
+		ConfirmDelete:           data.ConfirmDelete.ValueBool(),
+		ReadInFlightQueries:     data.ReadInFlightQueries.ValueBool(),
+		OrphanCheck:             data.OrphanCheck.ValueString(),
+		gatewayVersion:          &gatewayVersionCache{},
+	}
+	if !data.ExternalUrlDefault.IsNull() {
+		providerData.ExternalUrlDefault = data.ExternalUrlDefault.ValueString()
+	}
+	if !data.ImportExternalUrl.IsNull() {
+		providerData.ImportExternalUrl = data.ImportExternalUrl.ValueString()
+	}
+	if data.CheckProxyToDns.ValueBool() {
+		providerData.Resolver = net.DefaultResolver
+	}
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+}
+
+// parseDuration returns fallback for null value and adds diagnostic for invalid one.
+func parseDuration(diags *diag.Diagnostics, attribute string, value types.String, fallback time.Duration) time.Duration {
+	if value.IsNull() {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration <= 0 {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			"Invalid duration",
+			fmt.Sprintf("%s should be positive duration like `10s`, got %q", attribute, value.ValueString()),
+		)
+		return fallback
+	}
+	return duration
+}
+
+func checkMinGatewayVersion(ctx context.Context, client trinogatewayclient.TrinoGatewayClient, minVersion string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	version, err := client.GetGatewayVersion(ctx)
+	if err != nil {
+		diags.AddError(
+			"Cant get trino gateway version",
+			fmt.Sprintf("Cant get trino gateway version to compare with min_gateway_version: %s", err.Error()),
+		)
+		return diags
+	}
+	cmp, err := compareGatewayVersions(version, minVersion)
+	if err != nil {
+		diags.AddError("Cant compare trino gateway version", err.Error())
+		return diags
+	}
+	if cmp < 0 {
+		diags.AddError(
+			"Unsupported trino gateway version",
+			fmt.Sprintf("Trino gateway version %s is lower than min_gateway_version %s", version, minVersion),
+		)
+	}
+	return diags
 }
 
 func (p *TrinoGatewayProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBackendResource,
+		NewBackendMembershipResource,
+		NewDefaultRoutingGroupResource,
+		NewBackendSetResource,
 	}
 }
 
 func (p *TrinoGatewayProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewBackendsDataSource,
+		NewBackendActiveDataSource,
+		NewRoutingGroupBackendsDataSource,
+		NewBackendValidationDataSource,
+		NewRoutingGroupsDataSource,
+		NewBackendsExportDataSource,
+		NewRoutingGroupCapacityDataSource,
+		NewBackendByExternalUrlDataSource,
+		NewBackendHealthDataSource,
+		NewBackendHistoryDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
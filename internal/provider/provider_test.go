@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+func TestProviderApiKeyHeaderValidation(t *testing.T) {
+	ctx := context.Background()
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "http://gateway"),
+	})
+	resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: dynamicValue(t, server.schema.Provider.ValueType().(tftypes.Object), map[string]tftypes.Value{
+			"endpoint":       tftypes.NewValue(tftypes.String, "http://gateway"),
+			"api_key":        tftypes.NewValue(tftypes.String, "key"),
+			"api_key_header": tftypes.NewValue(tftypes.String, ""),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			return
+		}
+	}
+	t.Fatalf("got diagnostics %+v, want api_key_header error", resp.Diagnostics)
+}
+
+func newTestProviderServer(t *testing.T, config map[string]tftypes.Value) *testProviderServer {
+	server, diagnostics := configureTestProvider(t, config)
+	checkDiagnostics(t, diagnostics)
+	return server
+}
+
+// testProviderServer is provider configured with config, attributes missing in config are null.
+type testProviderServer struct {
+	tfprotov6.ProviderServer
+	schema *tfprotov6.GetProviderSchemaResponse
+}
+
+// resourceType returns object type of resource schema.
+func (s *testProviderServer) resourceType(t *testing.T, typeName string) tftypes.Object {
+	schema, ok := s.schema.ResourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("no resource %s", typeName)
+	}
+	return schema.ValueType().(tftypes.Object)
+}
+
+// dynamicValue encodes object of objectType from values, missing attributes are null.
+func dynamicValue(t *testing.T, objectType tftypes.Object, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	value, err := tfprotov6.NewDynamicValue(objectType, objectValue(objectType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &value
+}
+
+// configureTestProvider is newTestProviderServer returning configure diagnostics instead of checking them.
+func configureTestProvider(t *testing.T, config map[string]tftypes.Value) (*testProviderServer, []*tfprotov6.Diagnostic) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: dynamicValue(t, schema.Provider.ValueType().(tftypes.Object), config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testProviderServer{ProviderServer: server, schema: schema}, configureResp.Diagnostics
+}
+
+// checkDiagnostics fails test on error diagnostics.
+func checkDiagnostics(t *testing.T, diagnostics []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
+
+func TestCredentialsAreSensitive(t *testing.T) {
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "http://gateway"),
+	})
+	sensitive := func(attributes []*tfprotov6.SchemaAttribute) map[string]bool {
+		result := map[string]bool{}
+		for _, attribute := range attributes {
+			result[attribute.Name] = attribute.Sensitive
+		}
+		return result
+	}
+	providerAttributes := sensitive(server.schema.Provider.Block.Attributes)
+	for _, name := range []string{"login", "password", "api_key", "token_command", "headers"} {
+		if !providerAttributes[name] {
+			t.Errorf("provider %s is not sensitive", name)
+		}
+	}
+	var authAttributes map[string]bool
+	for _, attribute := range server.schema.ResourceSchemas["trinogateway_backend"].Block.Attributes {
+		if attribute.Name == "auth" {
+			authAttributes = sensitive(attribute.NestedType.Attributes)
+		}
+	}
+	for _, name := range []string{"password", "api_key"} {
+		if !authAttributes[name] {
+			t.Errorf("backend auth %s is not sensitive", name)
+		}
+	}
+}
+
+func TestProviderCheckCredentials(t *testing.T) {
+	gatewayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer gatewayServer.Close()
+	tests := []struct {
+		name             string
+		checkCredentials bool
+		wantErr          bool
+	}{
+		{name: "enabled", checkCredentials: true, wantErr: true},
+		{name: "disabled"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diagnostics := configureTestProvider(t, map[string]tftypes.Value{
+				"endpoint":          tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				"login":             tftypes.NewValue(tftypes.String, "admin"),
+				"password":          tftypes.NewValue(tftypes.String, "wrong"),
+				"check_credentials": tftypes.NewValue(tftypes.Bool, tt.checkCredentials),
+			})
+			hasErr := false
+			for _, diagnostic := range diagnostics {
+				if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+					hasErr = true
+					if diagnostic.Summary != "Authentication Error" {
+						t.Fatalf("got error %q, want authentication error", diagnostic.Summary)
+					}
+				}
+			}
+			if hasErr != tt.wantErr {
+				t.Fatalf("got diagnostics %+v, want error: %v", diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderListPath(t *testing.T) {
+	var listPath string
+	gatewayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listPath = r.URL.Path
+	}))
+	defer gatewayServer.Close()
+	tests := []struct {
+		name     string
+		listPath tftypes.Value
+		want     string
+	}{
+		{name: "default", listPath: tftypes.NewValue(tftypes.String, nil), want: trinogatewayclient.DefaultListPath},
+		{name: "custom", listPath: tftypes.NewValue(tftypes.String, "/api/public/backends"), want: "/api/public/backends"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listPath = ""
+			newTestProviderServer(t, map[string]tftypes.Value{
+				"endpoint":          tftypes.NewValue(tftypes.String, gatewayServer.URL),
+				"list_path":         tt.listPath,
+				"check_credentials": tftypes.NewValue(tftypes.Bool, true),
+			})
+			if listPath != tt.want {
+				t.Fatalf("credentials checked at %q, want %q", listPath, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderListPathValidation(t *testing.T) {
+	ctx := context.Background()
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "http://gateway"),
+	})
+	resp, err := server.ValidateProviderConfig(ctx, &tfprotov6.ValidateProviderConfigRequest{
+		Config: dynamicValue(t, server.schema.Provider.ValueType().(tftypes.Object), map[string]tftypes.Value{
+			"endpoint":  tftypes.NewValue(tftypes.String, "http://gateway"),
+			"list_path": tftypes.NewValue(tftypes.String, "api/public/backends"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			return
+		}
+	}
+	t.Fatalf("got diagnostics %+v, want list_path error", resp.Diagnostics)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// configuredResource configures resource with providerData and returns its schema object type.
+func configuredResource(t *testing.T, r resource.Resource, providerData *TrinoGatewayProviderData) (resource.SchemaResponse, tftypes.Object) {
+	ctx := context.Background()
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		configureResp := &resource.ConfigureResponse{}
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatal(configureResp.Diagnostics)
+		}
+	}
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	return schemaResp, schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+}
+
+// planCreate runs plan modification of new resource, planned values are config values,
+// attributes missing in config are null.
+func planCreate(t *testing.T, r resource.ResourceWithModifyPlan, providerData *TrinoGatewayProviderData, config map[string]tftypes.Value) *resource.ModifyPlanResponse {
+	schemaResp, objectType := configuredResource(t, r, providerData)
+	raw := objectValue(objectType, config)
+	resp := &resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+	}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}, resp)
+	return resp
+}
+
+// create creates resource from plan, attributes missing in plan are null.
+func create(t *testing.T, r resource.Resource, providerData *TrinoGatewayProviderData, plan map[string]tftypes.Value) *resource.CreateResponse {
+	schemaResp, objectType := configuredResource(t, r, providerData)
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	r.Create(context.Background(), resource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: objectValue(objectType, plan)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: objectValue(objectType, plan)},
+	}, resp)
+	return resp
+}
+
+// read refreshes resource state, attributes missing in state are null.
+func read(t *testing.T, r resource.Resource, providerData *TrinoGatewayProviderData, state map[string]tftypes.Value) *resource.ReadResponse {
+	schemaResp, objectType := configuredResource(t, r, providerData)
+	raw := objectValue(objectType, state)
+	resp := &resource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+	}
+	r.Read(context.Background(), resource.ReadRequest{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: raw},
+	}, resp)
+	return resp
+}
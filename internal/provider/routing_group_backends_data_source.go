@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoutingGroupBackendsDataSource{}
+
+func NewRoutingGroupBackendsDataSource() datasource.DataSource {
+	return &RoutingGroupBackendsDataSource{}
+}
+
+// RoutingGroupBackendsDataSource defines the data source implementation.
+type RoutingGroupBackendsDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// RoutingGroupBackendsDataSourceModel describes the data source data model.
+type RoutingGroupBackendsDataSourceModel struct {
+	RoutingGroup types.String                          `tfsdk:"routing_group"`
+	Backends     []RoutingGroupBackendsDataSourceEntry `tfsdk:"backends"`
+}
+
+type RoutingGroupBackendsDataSourceEntry struct {
+	Name   types.String `tfsdk:"name"`
+	Active types.Bool   `tfsdk:"active"`
+}
+
+func (d *RoutingGroupBackendsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_routing_group_backends"
+}
+
+func (d *RoutingGroupBackendsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Backends serving routing group",
+
+		Attributes: map[string]schema.Attribute{
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name",
+				Required:            true,
+			},
+			"backends": schema.ListNestedAttribute{
+				MarkdownDescription: "Member backends sorted by name. Empty for unknown routing group",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of backend",
+							Computed:            true,
+						},
+						"active": schema.BoolAttribute{
+							MarkdownDescription: "Backend activation",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RoutingGroupBackendsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *RoutingGroupBackendsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoutingGroupBackendsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backends, err := d.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+
+	data.Backends = []RoutingGroupBackendsDataSourceEntry{}
+	for _, backend := range groupBackendsByRoutingGroup(sortedBackends(backends))[data.RoutingGroup.ValueString()] {
+		data.Backends = append(data.Backends, RoutingGroupBackendsDataSourceEntry{
+			Name:   types.StringValue(backend.Name),
+			Active: types.BoolValue(backend.Active),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func groupBackendsByRoutingGroup(backends []*trinogatewayclient.Backend) map[string][]*trinogatewayclient.Backend {
+	groups := map[string][]*trinogatewayclient.Backend{}
+	for _, backend := range backends {
+		groups[backend.RoutingGroup] = append(groups[backend.RoutingGroup], backend)
+	}
+	return groups
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRoutingGroupBackendsDataSource(t *testing.T) {
+	client := backendsGateway(t, `[
+		{"name":"adhoc-2","routingGroup":"adhoc","active":false},
+		{"name":"etl-1","routingGroup":"etl","active":true},
+		{"name":"adhoc-1","routingGroup":"adhoc","active":true}
+	]`)
+	tests := []struct {
+		routingGroup string
+		want         []RoutingGroupBackendsDataSourceEntry
+	}{
+		{
+			routingGroup: "adhoc",
+			want: []RoutingGroupBackendsDataSourceEntry{
+				{Name: types.StringValue("adhoc-1"), Active: types.BoolValue(true)},
+				{Name: types.StringValue("adhoc-2"), Active: types.BoolValue(false)},
+			},
+		},
+		{routingGroup: "unknown", want: []RoutingGroupBackendsDataSourceEntry{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.routingGroup, func(t *testing.T) {
+			resp := readDataSource(t, NewRoutingGroupBackendsDataSource(), &TrinoGatewayProviderData{Client: client}, map[string]tftypes.Value{
+				"routing_group": tftypes.NewValue(tftypes.String, tt.routingGroup),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var data RoutingGroupBackendsDataSourceModel
+			if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !slices.Equal(data.Backends, tt.want) {
+				t.Fatalf("got backends %v, want %v", data.Backends, tt.want)
+			}
+		})
+	}
+}
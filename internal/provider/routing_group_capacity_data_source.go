@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoutingGroupCapacityDataSource{}
+
+func NewRoutingGroupCapacityDataSource() datasource.DataSource {
+	return &RoutingGroupCapacityDataSource{}
+}
+
+// RoutingGroupCapacityDataSource defines the data source implementation.
+type RoutingGroupCapacityDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// RoutingGroupCapacityDataSourceModel describes the data source data model.
+type RoutingGroupCapacityDataSourceModel struct {
+	RoutingGroup types.String `tfsdk:"routing_group"`
+	Total        types.Int64  `tfsdk:"total"`
+	Active       types.Int64  `tfsdk:"active"`
+}
+
+func (d *RoutingGroupCapacityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_routing_group_capacity"
+}
+
+func (d *RoutingGroupCapacityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Number of backends in routing group. Unknown routing group has zero backends",
+
+		Attributes: map[string]schema.Attribute{
+			"routing_group": schema.StringAttribute{
+				MarkdownDescription: "Routing group name",
+				Required:            true,
+			},
+			"total": schema.Int64Attribute{
+				MarkdownDescription: "Number of backends",
+				Computed:            true,
+			},
+			"active": schema.Int64Attribute{
+				MarkdownDescription: "Number of active backends",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoutingGroupCapacityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *RoutingGroupCapacityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoutingGroupCapacityDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capacity, err := d.client.GetRoutingGroupCapacity(ctx, data.RoutingGroup.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to get routing group capacity", err)
+		return
+	}
+	data.Total = types.Int64Value(capacity.Total)
+	data.Active = types.Int64Value(capacity.Active)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeRoutingGroup returns routing group as it is sent to gateway.
+func normalizeRoutingGroup(lowercase bool, routingGroup string) string {
+	if lowercase {
+		return strings.ToLower(routingGroup)
+	}
+	return routingGroup
+}
+
+// preserveRoutingGroup keeps prior value if gateway returned it lowercased by provider.
+func preserveRoutingGroup(lowercase bool, prior types.String, actual string) types.String {
+	if lowercase && !prior.IsNull() && !prior.IsUnknown() && strings.ToLower(prior.ValueString()) == actual {
+		return prior
+	}
+	return types.StringValue(actual)
+}
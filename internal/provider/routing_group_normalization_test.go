@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeRoutingGroup(t *testing.T) {
+	if got := normalizeRoutingGroup(false, "AdHoc"); got != "AdHoc" {
+		t.Fatalf("got %q, want routing group as is", got)
+	}
+	if got := normalizeRoutingGroup(true, "AdHoc"); got != "adhoc" {
+		t.Fatalf("got %q, want lowercased routing group", got)
+	}
+}
+
+func TestPreserveRoutingGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		lowercase bool
+		prior     types.String
+		actual    string
+		want      types.String
+	}{
+		{name: "lowercased by provider", lowercase: true, prior: types.StringValue("AdHoc"), actual: "adhoc", want: types.StringValue("AdHoc")},
+		{name: "changed outside", lowercase: true, prior: types.StringValue("AdHoc"), actual: "etl", want: types.StringValue("etl")},
+		{name: "no prior", lowercase: true, prior: types.StringNull(), actual: "adhoc", want: types.StringValue("adhoc")},
+		{name: "normalization disabled", lowercase: false, prior: types.StringValue("AdHoc"), actual: "adhoc", want: types.StringValue("adhoc")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preserveRoutingGroup(tt.lowercase, tt.prior, tt.actual); !got.Equal(tt.want) {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoutingGroupsDataSource{}
+
+func NewRoutingGroupsDataSource() datasource.DataSource {
+	return &RoutingGroupsDataSource{}
+}
+
+// RoutingGroupsDataSource defines the data source implementation.
+type RoutingGroupsDataSource struct {
+	client trinogatewayclient.TrinoGatewayClient
+}
+
+// RoutingGroupsDataSourceModel describes the data source data model.
+type RoutingGroupsDataSourceModel struct {
+	RoutingGroups []types.String `tfsdk:"routing_groups"`
+}
+
+func (d *RoutingGroupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_routing_groups"
+}
+
+func (d *RoutingGroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Routing groups known to gateway, derived from registered backends",
+
+		Attributes: map[string]schema.Attribute{
+			"routing_groups": schema.ListAttribute{
+				MarkdownDescription: "Sorted routing group names",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *RoutingGroupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*TrinoGatewayProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.TrinoGatewayProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *RoutingGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoutingGroupsDataSourceModel
+
+	backends, err := d.client.GetAllBackends(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list backends", err)
+		return
+	}
+
+	routingGroups := []string{}
+	for routingGroup := range groupBackendsByRoutingGroup(backends) {
+		routingGroups = append(routingGroups, routingGroup)
+	}
+	sort.Strings(routingGroups)
+
+	data.RoutingGroups = []types.String{}
+	for _, routingGroup := range routingGroups {
+		data.RoutingGroups = append(data.RoutingGroups, types.StringValue(routingGroup))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRoutingGroupsDataSource(t *testing.T) {
+	client := backendsGateway(t, `[
+		{"name":"etl-1","routingGroup":"etl","active":true},
+		{"name":"adhoc-1","routingGroup":"adhoc","active":false},
+		{"name":"etl-2","routingGroup":"etl","active":true}
+	]`)
+	resp := readDataSource(t, NewRoutingGroupsDataSource(), &TrinoGatewayProviderData{Client: client}, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var data RoutingGroupsDataSourceModel
+	if diags := resp.State.Get(context.Background(), &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := []types.String{types.StringValue("adhoc"), types.StringValue("etl")}
+	if !slices.Equal(data.RoutingGroups, want) {
+		t.Fatalf("got routing groups %v, want %v", data.RoutingGroups, want)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeUrl strips lone trailing slash after host: "http://host/" -> "http://host".
+// Urls with meaningful path are returned as is.
+func normalizeUrl(rawUrl string) string {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || parsed.Host == "" {
+		return rawUrl
+	}
+	if parsed.Path != "/" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return rawUrl
+	}
+	return strings.TrimSuffix(rawUrl, "/")
+}
+
+// inferEndpointScheme prefixes endpoint without scheme with https://.
+// Scheme is looked up as "://", url.Parse takes "host:port" for scheme and opaque part.
+func inferEndpointScheme(endpoint string) (string, bool) {
+	if strings.Contains(endpoint, "://") {
+		return endpoint, false
+	}
+	return "https://" + endpoint, true
+}
+
+func urlsEquivalent(a string, b string) bool {
+	return normalizeUrl(a) == normalizeUrl(b)
+}
+
+// preserveEquivalentUrl keeps prior value if it differs from actual only by normalization.
+func preserveEquivalentUrl(prior types.String, actual string) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && urlsEquivalent(prior.ValueString(), actual) {
+		return prior
+	}
+	return types.StringValue(actual)
+}
+
+var _ planmodifier.String = urlTrailingSlashPlanModifier{}
+
+// urlTrailingSlashPlanModifier keeps state value when planned url is equivalent to it.
+// Terraform accepts planned value equal to prior state instead of config,
+// so it works for not computed attributes too.
+type urlTrailingSlashPlanModifier struct{}
+
+func normalizeUrlTrailingSlash() planmodifier.String {
+	return urlTrailingSlashPlanModifier{}
+}
+
+func (m urlTrailingSlashPlanModifier) Description(ctx context.Context) string {
+	return "Urls differing only by trailing slash after host are considered equal."
+}
+
+func (m urlTrailingSlashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m urlTrailingSlashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if urlsEquivalent(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUrlsEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "http://trino:8080", b: "http://trino:8080/", want: true},
+		{a: "http://trino:8080/", b: "http://trino:8080/", want: true},
+		{a: "http://trino:8080/path", b: "http://trino:8080/path/", want: false},
+		{a: "http://trino:8080/?a=b", b: "http://trino:8080?a=b", want: false},
+		{a: "http://trino:8080", b: "https://trino:8080/", want: false},
+		{a: "not a url/", b: "not a url", want: false},
+	}
+	for _, tt := range tests {
+		if got := urlsEquivalent(tt.a, tt.b); got != tt.want {
+			t.Errorf("urlsEquivalent(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestPreserveEquivalentUrl(t *testing.T) {
+	tests := []struct {
+		name   string
+		prior  types.String
+		actual string
+		want   types.String
+	}{
+		{name: "equivalent", prior: types.StringValue("http://trino/"), actual: "http://trino", want: types.StringValue("http://trino/")},
+		{name: "changed", prior: types.StringValue("http://trino/"), actual: "http://other", want: types.StringValue("http://other")},
+		{name: "no prior", prior: types.StringNull(), actual: "http://trino", want: types.StringValue("http://trino")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preserveEquivalentUrl(tt.prior, tt.actual); !got.Equal(tt.want) {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackendProxyToTrailingSlashPlan(t *testing.T) {
+	ctx := context.Background()
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer gateway.Close()
+	server := newTestProviderServer(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, gateway.URL),
+	})
+	objectType := server.resourceType(t, "trinogateway_backend")
+	tests := []struct {
+		name   string
+		prior  string
+		config string
+		want   string
+	}{
+		{name: "added slash", prior: "http://trino-1:8080", config: "http://trino-1:8080/", want: "http://trino-1:8080"},
+		{name: "removed slash", prior: "https://trino-1/", config: "https://trino-1", want: "https://trino-1/"},
+		{name: "path slash", prior: "http://trino-1:8080/trino", config: "http://trino-1:8080/trino/", want: "http://trino-1:8080/trino/"},
+		{name: "query", prior: "http://trino-1:8080", config: "http://trino-1:8080/?a=b", want: "http://trino-1:8080/?a=b"},
+		{name: "scheme", prior: "http://trino-1:8080", config: "https://trino-1:8080/", want: "https://trino-1:8080/"},
+		{name: "host", prior: "http://trino-1:8080", config: "http://trino-2:8080/", want: "http://trino-2:8080/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := map[string]tftypes.Value{
+				"id":            tftypes.NewValue(tftypes.String, "trino-1"),
+				"name":          tftypes.NewValue(tftypes.String, "trino-1"),
+				"proxy_to":      tftypes.NewValue(tftypes.String, tt.prior),
+				"routing_group": tftypes.NewValue(tftypes.String, "adhoc"),
+				"external_url":  tftypes.NewValue(tftypes.String, "http://trino-1:8080"),
+				"active":        tftypes.NewValue(tftypes.Bool, false),
+			}
+			prior := dynamicValue(t, objectType, values)
+			values["proxy_to"] = tftypes.NewValue(tftypes.String, tt.config)
+			proposed := dynamicValue(t, objectType, values)
+			delete(values, "id")
+			config := dynamicValue(t, objectType, values)
+
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "trinogateway_backend",
+				PriorState:       prior,
+				ProposedNewState: proposed,
+				Config:           config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDiagnostics(t, planResp.Diagnostics)
+			planned, err := planResp.PlannedState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var attributes map[string]tftypes.Value
+			if err := planned.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			var proxyTo string
+			if err := attributes["proxy_to"].As(&proxyTo); err != nil {
+				t.Fatal(err)
+			}
+			if proxyTo != tt.want {
+				t.Fatalf("got planned proxy_to %q, want %q", proxyTo, tt.want)
+			}
+		})
+	}
+}
+
+func TestInferEndpointScheme(t *testing.T) {
+	tests := []struct {
+		endpoint     string
+		want         string
+		wantInferred bool
+	}{
+		{endpoint: "gateway.example.com", want: "https://gateway.example.com", wantInferred: true},
+		{endpoint: "gateway.example.com:8080/api", want: "https://gateway.example.com:8080/api", wantInferred: true},
+		{endpoint: "http://gateway.example.com", want: "http://gateway.example.com"},
+		{endpoint: "https://gateway.example.com", want: "https://gateway.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, inferred := inferEndpointScheme(tt.endpoint)
+			if got != tt.want || inferred != tt.wantInferred {
+				t.Fatalf("got %s, %v, want %s, %v", got, inferred, tt.want, tt.wantInferred)
+			}
+		})
+	}
+}
+
+func TestProviderEndpointWithoutSchemeWarning(t *testing.T) {
+	_, diagnostics := configureTestProvider(t, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "gateway.example.com"),
+	})
+	if len(diagnostics) != 1 || diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning || diagnostics[0].Summary != "Endpoint without scheme" {
+		t.Fatalf("got diagnostics %+v, want endpoint scheme warning", diagnostics)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	asyncOperationTimeout      = 5 * time.Minute
+	asyncOperationPollInterval = time.Second
+)
+
+// AsyncOperationError is returned when gateway reports failure of asynchronous mutation.
+type AsyncOperationError struct {
+	Location string
+	Status   string
+	Message  string
+}
+
+func (e *AsyncOperationError) Error() string {
+	return fmt.Sprintf("gateway operation %s finished with status %q: %s", e.Location, e.Status, e.Message)
+}
+
+// asyncOperation is operation resource of gateways accepting mutations asynchronously.
+// Upstream gateway applies mutations synchronously and never answers 202.
+type asyncOperation struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func (o *asyncOperation) done() (bool, error) {
+	switch strings.ToLower(o.Status) {
+	case "succeeded", "success", "completed", "done":
+		return true, nil
+	case "failed", "error", "canceled", "cancelled":
+		if o.Error == "" {
+			return true, errors.New("gateway gave no details")
+		}
+		return true, errors.New(o.Error)
+	}
+	return false, nil
+}
+
+// checkMutationResponse accepts 200, and 202 with Location header after operation behind it completes.
+func (tg *trinoGatewayClientHttpImpl) checkMutationResponse(ctx context.Context, response *http.Response, responseBody []byte) error {
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+	if response.StatusCode == http.StatusAccepted && response.Header.Get("Location") != "" {
+		return tg.waitAsyncOperation(ctx, response)
+	}
+	return badResponseError(response, responseBody)
+}
+
+// waitAsyncOperation polls operation from Location header until it succeeds, fails or asyncOperationTimeout passes.
+// Retry-After header of operation response overrides poll interval.
+func (tg *trinoGatewayClientHttpImpl) waitAsyncOperation(ctx context.Context, accepted *http.Response) error {
+	location, err := accepted.Request.URL.Parse(accepted.Header.Get("Location"))
+	if err != nil {
+		return fmt.Errorf("cant parse operation location: %w", err)
+	}
+	// credentials are sent to operation url, it must stay on gateway
+	if location.Host != accepted.Request.URL.Host {
+		return fmt.Errorf("operation location %s points outside of gateway", location)
+	}
+	ctx, cancel := context.WithTimeout(ctx, asyncOperationTimeout)
+	defer cancel()
+	interval := retryAfter(accepted, asyncOperationPollInterval)
+	for {
+		if err := tg.sleep(ctx, interval); err != nil {
+			return fmt.Errorf("cant wait gateway operation %s: %w", location, err)
+		}
+		request, err := tg.newRequest(ctx, http.MethodGet, "", nil)
+		if err != nil {
+			return fmt.Errorf("cant create request: %w", err)
+		}
+		request.URL = location
+		request.Host = location.Host
+		response, err := tg.do(request)
+		if err != nil {
+			return fmt.Errorf("cant get gateway operation %s: %w", location, err)
+		}
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLogSize))
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return badResponseError(response, responseBody)
+		}
+		operation := &asyncOperation{}
+		if err := json.Unmarshal(responseBody, operation); err != nil {
+			return fmt.Errorf("cant unmarshal gateway operation %s: %w, body: %s", location, err, responseBody)
+		}
+		done, err := operation.done()
+		if err != nil {
+			return &AsyncOperationError{Location: location.String(), Status: operation.Status, Message: err.Error()}
+		}
+		if done {
+			return nil
+		}
+		interval = retryAfter(response, asyncOperationPollInterval)
+	}
+}
+
+// retryAfter returns delay from Retry-After header in seconds, fallback if it is absent or not a number.
+func retryAfter(response *http.Response, fallback time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// asyncServer accepts every mutation with 202 and answers operation polls with given statuses in order.
+func asyncServer(t *testing.T, location string, statuses []string, polls *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/operations/1" {
+			i := int(polls.Add(1)) - 1
+			if i >= len(statuses) {
+				i = len(statuses) - 1
+			}
+			_, _ = w.Write([]byte(statuses[i]))
+			return
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAsyncMutations(t *testing.T) {
+	mutations := map[string]func(ctx context.Context, client TrinoGatewayClient) error{
+		"add backend": func(ctx context.Context, client TrinoGatewayClient) error {
+			return client.AddOrUpdateBackend(ctx, &Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "g"})
+		},
+		"delete backend": func(ctx context.Context, client TrinoGatewayClient) error {
+			return client.DeleteBackend(ctx, "b")
+		},
+		"deactivate backend": func(ctx context.Context, client TrinoGatewayClient) error {
+			return client.DeactivateBackend(ctx, "b")
+		},
+		"set default routing group": func(ctx context.Context, client TrinoGatewayClient) error {
+			return client.SetDefaultRoutingGroup(ctx, "g")
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var polls atomic.Int32
+			server := asyncServer(t, "/operations/1", []string{`{"status":"succeeded"}`}, &polls)
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := mutate(context.Background(), client); err != nil {
+				t.Fatal(err)
+			}
+			if got := polls.Load(); got != 1 {
+				t.Fatalf("operation polled %d times, want 1", got)
+			}
+		})
+	}
+}
+
+func TestAsyncMutationFailed(t *testing.T) {
+	var polls atomic.Int32
+	server := asyncServer(t, "/operations/1", []string{`{"status":"running"}`, `{"status":"failed","error":"backend is busy"}`}, &polls)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.DeactivateBackend(context.Background(), "b")
+	var operationErr *AsyncOperationError
+	if !errors.As(err, &operationErr) || operationErr.Message != "backend is busy" {
+		t.Fatalf("got %v, want AsyncOperationError", err)
+	}
+	if got := polls.Load(); got != 2 {
+		t.Fatalf("operation polled %d times, want 2", got)
+	}
+}
+
+func TestAsyncMutationLocationOutsideOfGateway(t *testing.T) {
+	var polls atomic.Int32
+	server := asyncServer(t, "http://other.example.com/operations/1", []string{`{"status":"succeeded"}`}, &polls)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetDefaultRoutingGroup(context.Background(), "g"); err == nil {
+		t.Fatal("want error")
+	}
+	if got := polls.Load(); got != 0 {
+		t.Fatalf("operation polled %d times, want 0", got)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import "sync"
+
+// backendsCache keeps last list response to answer 304 Not Modified.
+type backendsCache struct {
+	mu       sync.RWMutex
+	etag     string
+	backends []*Backend
+}
+
+func (c *backendsCache) getEtag() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.etag
+}
+
+// get returns copy of cached backends, so callers are free to modify them.
+func (c *backendsCache) get(etag string) ([]*Backend, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.etag == "" || c.etag != etag {
+		return nil, false
+	}
+	return copyBackends(c.backends), true
+}
+
+func (c *backendsCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.etag = ""
+	c.backends = nil
+}
+
+func (c *backendsCache) set(etag string, backends []*Backend) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.etag = etag
+	c.backends = copyBackends(backends)
+}
+
+func copyBackends(backends []*Backend) []*Backend {
+	result := make([]*Backend, 0, len(backends))
+	for _, backend := range backends {
+		backendCopy := *backend
+		result = append(result, &backendCopy)
+	}
+	return result
+}
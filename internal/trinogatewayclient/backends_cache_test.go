@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// etagServer serves one backend with ETag and answers 304 when client has it, it records If-None-Match headers.
+func etagServer(t *testing.T, ifNoneMatch *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			return
+		}
+		*ifNoneMatch = append(*ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`[{"name":"b","proxyTo":"http://b","routingGroup":"g","active":true}]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBackendsCacheNotModified(t *testing.T) {
+	var ifNoneMatch []string
+	server := etagServer(t, &ifNoneMatch)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := client.GetAllBackends(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// callers may modify returned backends, cache keeps its own copy
+	first[0].Active = false
+
+	second, err := client.GetAllBackends(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second) != 1 || second[0].Name != "b" || !second[0].Active {
+		t.Fatalf("got %+v from cache", second)
+	}
+	if len(ifNoneMatch) != 2 || ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` {
+		t.Fatalf("got If-None-Match headers %q", ifNoneMatch)
+	}
+}
+
+func TestBackendsCacheInvalidatedByMutation(t *testing.T) {
+	var ifNoneMatch []string
+	server := etagServer(t, &ifNoneMatch)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteBackend(context.Background(), "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(ifNoneMatch) != 2 || ifNoneMatch[1] != "" {
+		t.Fatalf("got If-None-Match headers %q, want none after mutation", ifNoneMatch)
+	}
+}
+
+func TestBackendsCacheNotModifiedWithoutCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err == nil {
+		t.Fatal("want error")
+	}
+}
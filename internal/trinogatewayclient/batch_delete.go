@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// deleteBackendsConcurrency bounds parallel deletes, so teardown does not overload gateway.
+const deleteBackendsConcurrency = 4
+
+// DeleteBackends deletes backends in parallel. Gateway has no batch delete endpoint,
+// so every backend is deleted by its own request. It keeps going after failed deletes,
+// returned error joins failures in order of names, each prefixed with backend name.
+func (tg *trinoGatewayClientHttpImpl) DeleteBackends(ctx context.Context, names []string) error {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
+	}
+	errs := make([]error, len(names))
+	semaphore := make(chan struct{}, deleteBackendsConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if err := tg.DeleteBackend(ctx, name); err != nil {
+				errs[i] = fmt.Errorf("backend %s: %w", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeleteBackends(t *testing.T) {
+	var backends []Backend
+	var names []string
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("trino-%d", i)
+		backends = append(backends, Backend{Name: name})
+		names = append(names, name)
+	}
+	gateway, server := newFakeGateway(t, append(backends, Backend{Name: "other"})...)
+	gateway.FailWrites("trino-7")
+	gateway.FailWrites("trino-2")
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.DeleteBackends(context.Background(), names)
+	if err == nil {
+		t.Fatal("want error")
+	}
+	message := err.Error()
+	failed2, failed7 := strings.Index(message, "backend trino-2:"), strings.Index(message, "backend trino-7:")
+	if failed2 < 0 || failed7 < 0 || failed2 > failed7 {
+		t.Fatalf("error does not list failed backends in order: %s", message)
+	}
+	if got, want := gateway.Names(), []string{"other", "trino-2", "trino-7"}; !slices.Equal(got, want) {
+		t.Fatalf("gateway has backends %q, want %q", got, want)
+	}
+}
+
+func TestDeleteBackendsConcurrency(t *testing.T) {
+	var inFlight, maxInFlight, deletes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		deletes.Add(1)
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for i := 0; i < 3*deleteBackendsConcurrency; i++ {
+		names = append(names, fmt.Sprintf("trino-%d", i))
+	}
+
+	if err := client.DeleteBackends(context.Background(), names); err != nil {
+		t.Fatal(err)
+	}
+	if got := deletes.Load(); got != int32(len(names)) {
+		t.Fatalf("gateway got %d deletes, want %d", got, len(names))
+	}
+	if got := maxInFlight.Load(); got > deleteBackendsConcurrency {
+		t.Fatalf("%d deletes in flight, limit is %d", got, deleteBackendsConcurrency)
+	}
+}
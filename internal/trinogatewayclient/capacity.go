@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import "context"
+
+// RoutingGroupCapacity is number of backends in routing group.
+type RoutingGroupCapacity struct {
+	Total  int64
+	Active int64
+}
+
+// GetRoutingGroupCapacity counts backends of routing group, unknown group has zero capacity.
+func (tg *trinoGatewayClientHttpImpl) GetRoutingGroupCapacity(ctx context.Context, routingGroup string) (*RoutingGroupCapacity, error) {
+	backends, err := tg.GetAllBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	capacity := &RoutingGroupCapacity{}
+	for _, backend := range backends {
+		if backend.RoutingGroup != routingGroup {
+			continue
+		}
+		capacity.Total++
+		if backend.Active {
+			capacity.Active++
+		}
+	}
+	return capacity, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetRoutingGroupCapacity(t *testing.T) {
+	server := staticServer(t, http.StatusOK, "application/json", `[
+		{"name":"adhoc-1","routingGroup":"adhoc","active":true},
+		{"name":"adhoc-2","routingGroup":"adhoc","active":false},
+		{"name":"adhoc-3","routingGroup":"adhoc","active":true},
+		{"name":"etl-1","routingGroup":"etl","active":false}
+	]`)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		routingGroup string
+		want         RoutingGroupCapacity
+	}{
+		{routingGroup: "adhoc", want: RoutingGroupCapacity{Total: 3, Active: 2}},
+		{routingGroup: "etl", want: RoutingGroupCapacity{Total: 1, Active: 0}},
+		{routingGroup: "unknown", want: RoutingGroupCapacity{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.routingGroup, func(t *testing.T) {
+			capacity, err := client.GetRoutingGroupCapacity(context.Background(), tt.routingGroup)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *capacity != tt.want {
+				t.Fatalf("got capacity %+v, want %+v", *capacity, tt.want)
+			}
+		})
+	}
+}
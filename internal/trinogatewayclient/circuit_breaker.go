@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending request while gateway is considered down.
+var ErrCircuitOpen = errors.New("circuit breaker is open: gateway failed repeatedly")
+
+// WithCircuitBreaker fails requests fast for cooldown after threshold consecutive failures within window.
+// Failures are network errors and 429/502/503/504 responses, every retry attempt counts.
+// After cooldown requests are let through again and the first failure opens breaker at once.
+func WithCircuitBreaker(threshold int, window time.Duration, cooldown time.Duration) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.breaker.threshold = threshold
+		tg.breaker.window = window
+		tg.breaker.cooldown = cooldown
+	}
+}
+
+type circuitBreaker struct {
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mu sync.Mutex
+	// failures are times of consecutive failures within window
+	failures  []time.Time
+	openUntil time.Time
+	// halfOpen is set after cooldown until next request outcome
+	halfOpen bool
+}
+
+func (b *circuitBreaker) allow() error {
+	if b.threshold == 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return nil
+	}
+	if remaining := time.Until(b.openUntil); remaining > 0 {
+		return fmt.Errorf("%w, next attempt in %s", ErrCircuitOpen, remaining.Round(time.Second))
+	}
+	b.openUntil = time.Time{}
+	b.halfOpen = true
+	return nil
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	if b.threshold == 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.failures = nil
+		b.halfOpen = false
+		return
+	}
+	now := time.Now()
+	if b.halfOpen {
+		b.open(now)
+		return
+	}
+	recent := b.failures[:0]
+	for _, failure := range b.failures {
+		if now.Sub(failure) <= b.window {
+			recent = append(recent, failure)
+		}
+	}
+	b.failures = append(recent, now)
+	if len(b.failures) >= b.threshold {
+		b.open(now)
+	}
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.openUntil = now.Add(b.cooldown)
+	b.failures = nil
+	b.halfOpen = false
+}
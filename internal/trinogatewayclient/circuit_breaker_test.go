@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	cooldown := 100 * time.Millisecond
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithCircuitBreaker(2, time.Minute, cooldown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := func() error {
+		_, err := client.GetAllBackends(context.Background())
+		return err
+	}
+
+	failing.Store(true)
+	for i := 0; i < 2; i++ {
+		if err := get(); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d got %v, want gateway error", i, err)
+		}
+	}
+	// open: request fails without reaching gateway
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("gateway got %d requests, want 2", got)
+	}
+
+	// half-open: single failure opens breaker again
+	time.Sleep(cooldown + 50*time.Millisecond)
+	if err := get(); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("half-open request got %v, want gateway error", err)
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want ErrCircuitOpen after failed half-open request", err)
+	}
+
+	// half-open: success closes breaker, single failure keeps it closed
+	time.Sleep(cooldown + 50*time.Millisecond)
+	failing.Store(false)
+	if err := get(); err != nil {
+		t.Fatalf("half-open request got %v", err)
+	}
+	failing.Store(true)
+	if err := get(); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got %v, want gateway error", err)
+	}
+	failing.Store(false)
+	if err := get(); err != nil {
+		t.Fatalf("got %v, breaker should stay closed below threshold", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithCircuitBreaker(1, time.Minute, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := client.GetAllBackends(context.Background()); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: breaker opened on 400 response", i)
+		}
+	}
+}
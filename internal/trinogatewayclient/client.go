@@ -7,22 +7,108 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
 const (
 	maxResponseBodyLogSize = 1024
+
+	DefaultDeletePath = "/gateway/backend/modify/delete"
+	DefaultListPath   = "/entity/GATEWAY_BACKEND"
+	// DefaultMaxResponseSize is generous enough for tens of thousands of backends.
+	DefaultMaxResponseSize = 64 << 20
 )
 
+var ErrBackendNotFound = errors.New("backend not found")
+
+// Backend mirrors gateway backend entity (ProxyBackendConfiguration).
 type Backend struct {
-	Name         string `json:"name"`
-	ProxyTo      string `json:"proxyTo"`
+	Name         string `json:"name" yaml:"name"`
+	ProxyTo      string `json:"proxyTo" yaml:"proxyTo"`
+	RoutingGroup string `json:"routingGroup" yaml:"routingGroup"`
+	Active       bool   `json:"active" yaml:"active"`
+	ExternalUrl  string `json:"externalUrl" yaml:"externalUrl"`
+	// Weight is not part of upstream ProxyBackendConfiguration, it is for gateway forks with weighted
+	// routing inside routing group. Nil weight is not sent, explicit zero is.
+	Weight *Number `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+// Equal reports whether backends have the same fields.
+func (b *Backend) Equal(other *Backend) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	if (b.Weight == nil) != (other.Weight == nil) || (b.Weight != nil && *b.Weight != *other.Weight) {
+		return false
+	}
+	return b.Name == other.Name &&
+		b.ProxyTo == other.ProxyTo &&
+		b.RoutingGroup == other.RoutingGroup &&
+		b.Active == other.Active &&
+		b.ExternalUrl == other.ExternalUrl
+}
+
+// Validate returns all problems found in backend, nil if backend is valid.
+func (b *Backend) Validate() []error {
+	var errs []error
+	if b.Name == "" {
+		errs = append(errs, fmt.Errorf("name is empty"))
+	}
+	if b.RoutingGroup == "" {
+		errs = append(errs, fmt.Errorf("routing group is empty"))
+	}
+	if err := ValidateBackendUrl(b.ProxyTo); err != nil {
+		errs = append(errs, fmt.Errorf("proxy to: %w", err))
+	}
+	if b.ExternalUrl != "" {
+		if err := ValidateBackendUrl(b.ExternalUrl); err != nil {
+			errs = append(errs, fmt.Errorf("external url: %w", err))
+		}
+	}
+	if b.Weight != nil && *b.Weight < 0 {
+		errs = append(errs, fmt.Errorf("weight is negative"))
+	}
+	return errs
+}
+
+// ValidateBackendUrl checks that url is absolute http or https url.
+func ValidateBackendUrl(rawUrl string) error {
+	if rawUrl == "" {
+		return fmt.Errorf("url is empty")
+	}
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", rawUrl, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("url %q should use http or https scheme", rawUrl)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("url %q has no host", rawUrl)
+	}
+	return nil
+}
+
+type defaultRoutingGroupResponse struct {
 	RoutingGroup string `json:"routingGroup"`
-	Active       bool   `json:"active"`
-	ExternalUrl  string `json:"externalUrl"`
+}
+
+// BackendDefaults are gateway-wide conventions for new backends.
+type BackendDefaults struct {
+	RoutingGroup string `json:"routingGroup"`
+	// ExternalUrlTemplate may contain {name} placeholder replaced by backend name
+	ExternalUrlTemplate string `json:"externalUrlTemplate"`
+}
+
+type gatewayVersionResponse struct {
+	Version string `json:"version"`
 }
 
 type Auth struct {
@@ -30,108 +116,560 @@ type Auth struct {
 	Password string
 }
 
+type Option func(tg *trinoGatewayClientHttpImpl)
+
+// WithAPIKey authenticates every request with a static key sent in the given header.
+func WithAPIKey(header string, key string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.apiKeyHeader = header
+		tg.apiKey = key
+	}
+}
+
+// WithAuthOverride replaces auth set by NewTrinoGatewayClient and other options with basic auth or api key,
+// for backends managed by another gateway account. Nil auth and empty apiKey leave client without auth.
+func WithAuthOverride(auth *Auth, apiKeyHeader string, apiKey string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.auth = auth
+		tg.apiKeyHeader = apiKeyHeader
+		tg.apiKey = apiKey
+		tg.tokenSource = nil
+	}
+}
+
+// WithHeaders adds headers to every request, they override default ones like Accept.
+func WithHeaders(headers map[string]string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.headers = headers
+	}
+}
+
+// WithStrictJSON makes unknown fields in backends list an error, to catch gateway schema drift.
+func WithStrictJSON() Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.strictJSON = true
+	}
+}
+
+// WithReadOnly makes every mutating method fail with ErrReadOnly, reads keep working.
+func WithReadOnly() Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.readOnly = true
+	}
+}
+
+// WithListPath sets path of backends list request, it differs across gateway versions.
+func WithListPath(path string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.listPath = path
+	}
+}
+
+// WithDeletePath sets path of delete request, it differs across gateway deployments.
+func WithDeletePath(path string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.deletePath = path
+	}
+}
+
+// WithMaxResponseSize limits size of backends list response in bytes, bigger responses fail with ResponseTooLargeError.
+func WithMaxResponseSize(size int64) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.maxResponseSize = size
+	}
+}
+
+// WithDeleteMethod sets http method of delete request, some gateway versions expect DELETE.
+func WithDeleteMethod(method string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.deleteMethod = method
+	}
+}
+
 type TrinoGatewayClient interface {
 	AddOrUpdateBackend(ctx context.Context, backend *Backend) error
 	DeleteBackend(ctx context.Context, name string) error
+	// DeleteBackends deletes backends with bounded concurrency, error joins per backend failures
+	DeleteBackends(ctx context.Context, names []string) error
+	DeactivateBackend(ctx context.Context, name string) error
+	// Authenticate verifies credentials without side effects
+	Authenticate(ctx context.Context) error
 	GetAllBackends(ctx context.Context) ([]*Backend, error)
+	// GetBackend returns ErrBackendNotFound if there is no backend with such name
+	GetBackend(ctx context.Context, name string) (*Backend, error)
+	// GetDefaultRoutingGroup returns empty string if gateway does not report default routing group
+	GetDefaultRoutingGroup(ctx context.Context) (string, error)
+	SetDefaultRoutingGroup(ctx context.Context, routingGroup string) error
+	// ReplaceAllBackends adds, updates and deletes backends to match desired set
+	ReplaceAllBackends(ctx context.Context, desired []*Backend) (*ReplaceSummary, error)
+	// ReconcileBackends is like ReplaceAllBackends, but deletes only backends listed in owned
+	ReconcileBackends(ctx context.Context, desired []*Backend, owned []string) (*ReplaceSummary, error)
+	// GetBackendDefaults returns nil if gateway does not expose defaults
+	GetBackendDefaults(ctx context.Context) (*BackendDefaults, error)
+	GetGatewayVersion(ctx context.Context) (string, error)
+	// UpdateBackendIfMatch returns ConflictError if current BackendVersion differs from expectedVersion,
+	// the check is atomic only on gateways supporting If-Match
+	UpdateBackendIfMatch(ctx context.Context, backend *Backend, expectedVersion string) error
+	// ExportBackends returns all backends as json with stable ordering
+	ExportBackends(ctx context.Context) ([]byte, error)
+	// GetRoutingGroupCapacity returns zero capacity for unknown routing group
+	GetRoutingGroupCapacity(ctx context.Context, routingGroup string) (*RoutingGroupCapacity, error)
+	// GetBackendInFlightQueries returns nil if gateway does not report backend state
+	GetBackendInFlightQueries(ctx context.Context, name string) (*int64, error)
+	// ProbeBackend returns unhealthy result, not error, if backend cant be probed
+	ProbeBackend(ctx context.Context, name string) (*BackendHealth, error)
+	// GetBackendHistory returns empty list if gateway keeps no backend history
+	GetBackendHistory(ctx context.Context, name string) ([]*BackendStateRecord, error)
+	// RunId returns id sent to gateway in run id header
+	RunId() string
+	// Close cancels in-flight requests and releases idle connections. Requests made after Close fail.
+	// Plugin framework has no provider shutdown hook, so it is up to embedders to call it.
+	Close() error
 }
 
-func NewTrinoGatewayClient(endpoint string, auth *Auth) (TrinoGatewayClient, error) {
-	return &trinoGatewayClientHttpImpl{
-		auth:       auth,
-		endpoint:   endpoint,
-		httpclient: http.DefaultClient,
-	}, nil
+func NewTrinoGatewayClient(endpoint string, auth *Auth, opts ...Option) (TrinoGatewayClient, error) {
+	tg := &trinoGatewayClientHttpImpl{
+		auth:         auth,
+		endpoint:     endpoint,
+		observer:     noopRequestObserver{},
+		deleteMethod: http.MethodPost,
+		deletePath:   DefaultDeletePath,
+		listPath:     DefaultListPath,
+		retry:        retryConfig{idempotencyKeyHeader: DefaultIdempotencyKeyHeader},
+
+		maxResponseSize: DefaultMaxResponseSize,
+		transportConfig: &transportConfig{
+			dialTimeout: defaultDialTimeout,
+			keepAlive:   defaultKeepAlive,
+		},
+	}
+	for _, opt := range opts {
+		opt(tg)
+	}
+	authMethods := 0
+	for _, configured := range []bool{tg.auth != nil, tg.apiKey != "", tg.tokenSource != nil} {
+		if configured {
+			authMethods++
+		}
+	}
+	if authMethods > 1 {
+		return nil, fmt.Errorf("basic auth, api key and bearer token are mutually exclusive")
+	}
+	if tg.runId == "" {
+		runId, err := newRunId()
+		if err != nil {
+			return nil, fmt.Errorf("cant generate run id: %w", err)
+		}
+		tg.runId = runId
+	}
+	// dedicated transport, so aliased providers dont share connections and settings
+	transport, err := tg.transportConfig.newTransport()
+	if err != nil {
+		return nil, err
+	}
+	tg.transport = transport
+	tg.httpclient = &http.Client{Transport: wrapTransport(transport, tg.middlewares)}
+	tg.probeClient = &http.Client{Transport: transport}
+	tg.closed, tg.close = context.WithCancel(context.Background())
+	tg.traceLogging = traceLoggingEnabled()
+	return tg, nil
 }
 
 type trinoGatewayClientHttpImpl struct {
-	httpclient *http.Client
-	auth       *Auth
-	endpoint   string
+	httpclient   *http.Client
+	auth         *Auth
+	apiKey       string
+	apiKeyHeader string
+	endpoint     string
+	observer     RequestObserver
+	tokenSource  TokenSource
+	token        cachedToken
+	strictJSON   bool
+	contentType  string
+	headers      map[string]string
+	deleteMethod string
+	deletePath   string
+	listPath     string
+	retry        retryConfig
+	readOnly     bool
+	// maintenanceWindow is nil unless WithMaintenanceWindow is set
+	maintenanceWindow *MaintenanceWindow
+	// maxResponseSize guards provider memory from misbehaving gateway
+	maxResponseSize int64
+	runIdHeader     string
+	// compressRequests gzips request bodies
+	compressRequests bool
+	streamingList    bool
+	// traceLogging enables request dumps, they are expensive to build
+	traceLogging         bool
+	omitEmptyFields      bool
+	slowRequestThreshold time.Duration
+	// runId correlates gateway requests of one terraform run
+	runId string
+
+	// closed is canceled by Close
+	closed context.Context
+	close  context.CancelFunc
+
+	transportConfig *transportConfig
+	// transport is unwrapped transport of httpclient
+	transport *http.Transport
+	// probeClient sends backend probes, gateway middlewares are not applied to backends
+	probeClient *http.Client
+	middlewares []Middleware
+	// backendLocks serializes mutations of the same backend
+	backendLocks keyedMutex
+	// breaker is disabled unless WithCircuitBreaker is set
+	breaker circuitBreaker
+	// backendsCache holds last backends list with its ETag
+	backendsCache backendsCache
+}
+
+// badResponseError describes unexpected response. Reverse proxies in front of gateway
+// usually answer with html pages, which are useless in error message.
+func badResponseError(response *http.Response, responseBody []byte) error {
+	if response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden {
+		return &AuthError{StatusCode: response.StatusCode}
+	}
+	mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if mediaType == "text/html" {
+		return &StatusError{
+			StatusCode: response.StatusCode,
+			message: fmt.Sprintf(
+				"gateway returned an HTML error page, likely a proxy/gateway-down issue, http response code: %d",
+				response.StatusCode,
+			),
+		}
+	}
+	return &StatusError{
+		StatusCode: response.StatusCode,
+		message: fmt.Sprintf(
+			"bad http response code: %d, body: %s",
+			response.StatusCode,
+			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
+		),
+	}
+}
+
+// limitedBuffer keeps first limit bytes written to it and discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// countingReader counts bytes read through it.
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
 }
 
 func (tg *trinoGatewayClientHttpImpl) getFullUrl(subpath string) string {
 	return strings.TrimSuffix(tg.endpoint, "/") + subpath
 }
 
+// newRequest builds gateway request with common headers and auth.
+func (tg *trinoGatewayClientHttpImpl) newRequest(ctx context.Context, method string, subpath string, body io.Reader) (*http.Request, error) {
+	if tg.compressRequests && body != nil {
+		compressed, err := gzipBody(body)
+		if err != nil {
+			return nil, err
+		}
+		body = compressed
+	}
+	request, err := http.NewRequestWithContext(ctx, method, tg.getFullUrl(subpath), body)
+	if err != nil {
+		return nil, err
+	}
+	if tg.compressRequests && body != nil {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	if method == http.MethodGet {
+		request.Header.Set("Accept", "application/json")
+	}
+	for name, value := range tg.headers {
+		request.Header.Set(name, value)
+	}
+	tg.setRunId(request)
+	tg.addAuth(request)
+	return request, nil
+}
+
 func (tg *trinoGatewayClientHttpImpl) addAuth(request *http.Request) {
 	if tg.auth != nil {
 		request.SetBasicAuth(tg.auth.Login, tg.auth.Password)
 	}
+	if tg.apiKey != "" {
+		request.Header.Set(tg.apiKeyHeader, tg.apiKey)
+	}
+}
+
+// do sends request with bearer token if configured. Expired token is refreshed once.
+func (tg *trinoGatewayClientHttpImpl) do(request *http.Request) (*http.Response, error) {
+	if tg.tokenSource == nil {
+		return tg.send(request)
+	}
+	ctx := request.Context()
+	token, err := tg.token.get(ctx, tg.tokenSource, false)
+	if err != nil {
+		return nil, fmt.Errorf("cant get bearer token: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := tg.send(request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+	token, err = tg.token.get(ctx, tg.tokenSource, true)
+	if err != nil {
+		return nil, fmt.Errorf("cant refresh bearer token: %w", err)
+	}
+	retry, err := rewindRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return tg.send(retry)
+}
+
+func (tg *trinoGatewayClientHttpImpl) Close() error {
+	tg.close()
+	tg.transport.CloseIdleConnections()
+	return nil
+}
+
+func (tg *trinoGatewayClientHttpImpl) AddOrUpdateBackend(ctx context.Context, backend *Backend) (err error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
+	}
+	defer func() { tg.observeMutation(OperationAddOrUpdateBackend, backend.Name, err) }()
+	defer tg.backendLocks.Lock(backend.Name)()
+	defer tg.backendsCache.invalidate()
+
+	return tg.postBackend(ctx, backend, "")
 }
-func (tg *trinoGatewayClientHttpImpl) AddOrUpdateBackend(ctx context.Context, backend *Backend) error {
-	requestBody, err := json.Marshal(backend)
+
+// postBackend creates or replaces backend, caller is responsible for locking.
+// Gateway has no partial update (e.g. merge-patch) endpoint, so whole backend is always sent.
+// postBackend writes backend, with not empty ifMatch only if backends list still has this ETag.
+func (tg *trinoGatewayClientHttpImpl) postBackend(ctx context.Context, backend *Backend, ifMatch string) error {
+	requestBody, err := tg.marshalBackend(backend)
 	if err != nil {
 		return fmt.Errorf("cant marshal backend: %w", err)
 	}
 
-	request, err := http.NewRequest(
-		http.MethodPost,
-		tg.getFullUrl("/entity?entityType=GATEWAY_BACKEND"),
-		bytes.NewReader(requestBody),
-	)
+	request, err := tg.newRequest(ctx, http.MethodPost, "/entity?entityType=GATEWAY_BACKEND", bytes.NewReader(requestBody))
 	if err != nil {
 		return fmt.Errorf("cant create request: %w", err)
 	}
-	tg.addAuth(request)
+	tg.setEntityHeaders(request)
+	if ifMatch != "" {
+		request.Header.Set("If-Match", ifMatch)
+	}
+	if err := tg.setIdempotencyKey(request); err != nil {
+		return err
+	}
 
-	response, err := tg.httpclient.Do(request)
+	response, err := tg.do(request)
 	if err != nil {
 		return fmt.Errorf("cant send request: %w", err)
 	}
 	defer response.Body.Close()
 	responseBody, _ := io.ReadAll(response.Body)
 
-	if response.StatusCode != 200 {
-		return fmt.Errorf(
-			"bad http response code: %d, body: %s",
-			response.StatusCode,
-			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
-		)
+	return tg.checkMutationResponse(ctx, response, responseBody)
+}
+
+func (tg *trinoGatewayClientHttpImpl) DeleteBackend(ctx context.Context, name string) (err error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
 	}
-	return nil
+	defer func() { tg.observeMutation(OperationDeleteBackend, name, err) }()
+	defer tg.backendLocks.Lock(name)()
+	defer tg.backendsCache.invalidate()
+
+	request, err := tg.newRequest(ctx, tg.deleteMethod, tg.deletePath, strings.NewReader(name))
+	if err != nil {
+		return fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(response.Body)
+
+	return tg.checkMutationResponse(ctx, response, responseBody)
 }
 
-func (tg *trinoGatewayClientHttpImpl) DeleteBackend(ctx context.Context, name string) error {
-	request, err := http.NewRequest(
-		http.MethodPost,
-		tg.getFullUrl("/gateway/backend/modify/delete"),
-		strings.NewReader(name),
-	)
+// DeactivateBackend uses gateway deactivate endpoint, which lets gateway drain backend gracefully.
+func (tg *trinoGatewayClientHttpImpl) DeactivateBackend(ctx context.Context, name string) (err error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
+	}
+	defer func() { tg.observeMutation(OperationDeactivateBackend, name, err) }()
+	defer tg.backendLocks.Lock(name)()
+	defer tg.backendsCache.invalidate()
+
+	request, err := tg.newRequest(ctx, http.MethodPost, "/gateway/backend/deactivate/"+url.PathEscape(name), nil)
 	if err != nil {
 		return fmt.Errorf("cant create request: %w", err)
 	}
-	tg.addAuth(request)
 
-	response, err := tg.httpclient.Do(request)
+	response, err := tg.do(request)
 	if err != nil {
 		return fmt.Errorf("cant send request: %w", err)
 	}
 	defer response.Body.Close()
 	responseBody, _ := io.ReadAll(response.Body)
 
+	return tg.checkMutationResponse(ctx, response, responseBody)
+}
+
+// Authenticate makes read-only authenticated request, gateway has no dedicated whoami endpoint.
+// Rejected credentials give AuthError.
+func (tg *trinoGatewayClientHttpImpl) Authenticate(ctx context.Context) error {
+	request, err := tg.newRequest(ctx, http.MethodGet, tg.listPath, nil)
+	if err != nil {
+		return fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+
 	if response.StatusCode != 200 {
-		return fmt.Errorf(
-			"bad http response code: %d, body: %s",
-			response.StatusCode,
-			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
-		)
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLogSize))
+		return badResponseError(response, responseBody)
 	}
+	_, _ = io.Copy(io.Discard, response.Body)
 	return nil
 }
 
 func (tg *trinoGatewayClientHttpImpl) GetAllBackends(ctx context.Context) ([]*Backend, error) {
-	request, err := http.NewRequest(
-		http.MethodGet,
-		tg.getFullUrl("/entity/GATEWAY_BACKEND"),
-		nil,
-	)
+	request, err := tg.newRequest(ctx, http.MethodGet, tg.listPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cant create request: %w", err)
+	}
+	tg.setEntityHeaders(request)
+	tg.setStreamingHeaders(request)
+	cachedEtag := tg.backendsCache.getEtag()
+	if cachedEtag != "" {
+		request.Header.Set("If-None-Match", cachedEtag)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified {
+		if cached, ok := tg.backendsCache.get(cachedEtag); ok {
+			tg.observeCache(true)
+			return cached, nil
+		}
+		tg.observeCache(false)
+		return nil, fmt.Errorf("gateway responded not modified, but there is no cached backends for etag %s", cachedEtag)
+	}
+	if response.StatusCode != 200 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLogSize))
+		return nil, badResponseError(response, responseBody)
+	}
+
+	// decode while reading, gateways with thousands of backends return large bodies
+	preview := &limitedBuffer{limit: maxResponseBodyLogSize}
+	body := &countingReader{reader: io.LimitReader(response.Body, tg.maxResponseSize+1)}
+	var allBackends []*Backend
+	if isNdjsonResponse(response) {
+		allBackends, err = tg.decodeBackendsStream(ctx, io.TeeReader(body, preview))
+	} else {
+		allBackends, err = tg.decodeBackends(io.TeeReader(body, preview))
+	}
+	if body.count > tg.maxResponseSize {
+		return nil, &ResponseTooLargeError{Limit: tg.maxResponseSize}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cant unmarshal response: %w, body: %s", err, preview.Bytes())
+	}
+	tg.observeCache(false)
+	if etag := response.Header.Get("ETag"); etag != "" {
+		tg.backendsCache.set(etag, allBackends)
+	}
+	return allBackends, nil
+}
+
+func (tg *trinoGatewayClientHttpImpl) GetBackend(ctx context.Context, name string) (*Backend, error) {
+	backends, err := tg.GetAllBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range backends {
+		if backend.Name == name {
+			return backend, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrBackendNotFound, name)
+}
+
+func (tg *trinoGatewayClientHttpImpl) GetDefaultRoutingGroup(ctx context.Context) (string, error) {
+	request, err := tg.newRequest(ctx, http.MethodGet, "/gateway/routingGroup/default", nil)
+	if err != nil {
+		return "", fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return "", fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("cant read response body")
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if response.StatusCode != 200 {
+		return "", badResponseError(response, responseBody)
+	}
+
+	defaultRoutingGroup := &defaultRoutingGroupResponse{}
+	if err := json.Unmarshal(responseBody, defaultRoutingGroup); err != nil {
+		return "", fmt.Errorf(
+			"cant unmarshal response: %w, body: %s",
+			err,
+			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
+		)
+	}
+	return defaultRoutingGroup.RoutingGroup, nil
+}
+
+func (tg *trinoGatewayClientHttpImpl) GetBackendDefaults(ctx context.Context) (*BackendDefaults, error) {
+	request, err := tg.newRequest(ctx, http.MethodGet, "/gateway/backend/defaults", nil)
 	if err != nil {
 		return nil, fmt.Errorf("cant create request: %w", err)
 	}
-	tg.addAuth(request)
 
-	response, err := tg.httpclient.Do(request)
+	response, err := tg.do(request)
 	if err != nil {
 		return nil, fmt.Errorf("cant send request: %w", err)
 	}
@@ -141,21 +679,76 @@ func (tg *trinoGatewayClientHttpImpl) GetAllBackends(ctx context.Context) ([]*Ba
 		return nil, fmt.Errorf("cant read response body")
 	}
 
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
 	if response.StatusCode != 200 {
+		return nil, badResponseError(response, responseBody)
+	}
+
+	defaults := &BackendDefaults{}
+	if err := json.Unmarshal(responseBody, defaults); err != nil {
 		return nil, fmt.Errorf(
-			"bad http response code: %d, body: %s",
-			response.StatusCode,
+			"cant unmarshal response: %w, body: %s",
+			err,
 			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
 		)
 	}
+	return defaults, nil
+}
 
-	allBackends := []*Backend{}
-	if err := json.Unmarshal(responseBody, &allBackends); err != nil {
-		return nil, fmt.Errorf(
+func (tg *trinoGatewayClientHttpImpl) GetGatewayVersion(ctx context.Context) (string, error) {
+	request, err := tg.newRequest(ctx, http.MethodGet, "/gateway/version", nil)
+	if err != nil {
+		return "", fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return "", fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("cant read response body")
+	}
+
+	if response.StatusCode != 200 {
+		return "", badResponseError(response, responseBody)
+	}
+
+	gatewayVersion := &gatewayVersionResponse{}
+	if err := json.Unmarshal(responseBody, gatewayVersion); err != nil {
+		return "", fmt.Errorf(
 			"cant unmarshal response: %w, body: %s",
 			err,
 			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
 		)
 	}
-	return allBackends, nil
+	return gatewayVersion.Version, nil
+}
+
+func (tg *trinoGatewayClientHttpImpl) SetDefaultRoutingGroup(ctx context.Context, routingGroup string) (err error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
+	}
+	defer func() { tg.observeMutation(OperationSetDefaultRoutingGroup, routingGroup, err) }()
+	requestBody, err := json.Marshal(&defaultRoutingGroupResponse{RoutingGroup: routingGroup})
+	if err != nil {
+		return fmt.Errorf("cant marshal default routing group: %w", err)
+	}
+
+	request, err := tg.newRequest(ctx, http.MethodPost, "/gateway/routingGroup/default", bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(response.Body)
+
+	return tg.checkMutationResponse(ctx, response, responseBody)
 }
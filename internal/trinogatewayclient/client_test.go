@@ -0,0 +1,423 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAPIKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		auth       *Auth
+		opts       []Option
+		wantHeader string
+		wantKey    string
+		wantBasic  bool
+	}{
+		{
+			name:       "api key",
+			opts:       []Option{WithAPIKey("X-API-Key", "key")},
+			wantHeader: "X-API-Key",
+			wantKey:    "key",
+		},
+		{
+			name:       "custom header",
+			opts:       []Option{WithAPIKey("X-Gateway-Token", "key")},
+			wantHeader: "X-Gateway-Token",
+			wantKey:    "key",
+		},
+		{
+			name:       "override replaces basic auth",
+			auth:       &Auth{Login: "admin", Password: "secret"},
+			opts:       []Option{WithAuthOverride(nil, "X-API-Key", "other-key")},
+			wantHeader: "X-API-Key",
+			wantKey:    "other-key",
+		},
+		{
+			name:       "override replaces api key",
+			opts:       []Option{WithAPIKey("X-API-Key", "key"), WithAuthOverride(&Auth{Login: "admin", Password: "secret"}, "", "")},
+			wantHeader: "X-API-Key",
+			wantBasic:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header = r.Header.Clone()
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, tt.auth, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got := header.Get(tt.wantHeader); got != tt.wantKey {
+				t.Fatalf("header %s is %q, want %q", tt.wantHeader, got, tt.wantKey)
+			}
+			if got := header.Get("Authorization") != ""; got != tt.wantBasic {
+				t.Fatalf("authorization header sent: %v, want %v", got, tt.wantBasic)
+			}
+		})
+	}
+}
+
+func TestAPIKeyConflictsWithBasicAuth(t *testing.T) {
+	_, err := NewTrinoGatewayClient("http://gateway", &Auth{Login: "admin", Password: "secret"}, WithAPIKey("X-API-Key", "key"))
+	if err == nil {
+		t.Fatal("want error")
+	}
+}
+
+func TestAddOrUpdateBackendWeight(t *testing.T) {
+	zero := Number(0)
+	tests := []struct {
+		name       string
+		weight     *Number
+		wantWeight bool
+	}{
+		{name: "unset", weight: nil, wantWeight: false},
+		{name: "explicit zero", weight: &zero, wantWeight: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &sent); err != nil {
+					t.Errorf("cant decode request: %v", err)
+				}
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backend := &Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "g", Weight: tt.weight}
+			if err := client.AddOrUpdateBackend(context.Background(), backend); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := sent["weight"]; ok != tt.wantWeight {
+				t.Fatalf("weight sent: %v, want %v: %v", ok, tt.wantWeight, sent)
+			}
+		})
+	}
+}
+
+func TestBackendEqualWeight(t *testing.T) {
+	zero, one := Number(0), Number(1)
+	unset := &Backend{Name: "b"}
+	if unset.Equal(&Backend{Name: "b", Weight: &zero}) {
+		t.Fatal("unset weight equals zero weight")
+	}
+	if !(&Backend{Name: "b", Weight: &one}).Equal(&Backend{Name: "b", Weight: &one}) {
+		t.Fatal("same weights are not equal")
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	server := staticServer(t, http.StatusOK, "application/json", `[{"name":"trino-1","proxyTo":"http://trino-1","routingGroup":"adhoc","active":true,"unknownField":1}]`)
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{name: "lenient"},
+		{name: "strict", opts: []Option{WithStrictJSON()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backends, err := client.GetAllBackends(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), "unknownField") {
+					t.Fatalf("error %q does not name unknown field", err)
+				}
+				return
+			}
+			if len(backends) != 1 || backends[0].Name != "trino-1" {
+				t.Fatalf("got backends %v", backends)
+			}
+		})
+	}
+}
+
+// recordingServer answers 200 with body to every request and records "method path body" of requests.
+func recordingServer(t *testing.T, body string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	requests := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBody, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		*requests = append(*requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(requestBody)))
+		mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestDeleteBackendMethod(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "POST " + DefaultDeletePath + " trino-1"},
+		{name: "delete", opts: []Option{WithDeleteMethod(http.MethodDelete)}, want: "DELETE " + DefaultDeletePath + " trino-1"},
+		{name: "path", opts: []Option{WithDeletePath("/api/backend/delete")}, want: "POST /api/backend/delete trino-1"},
+		{name: "method and path", opts: []Option{WithDeleteMethod(http.MethodDelete), WithDeletePath("/api/backend/delete")}, want: "DELETE /api/backend/delete trino-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := recordingServer(t, "")
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := client.DeleteBackend(context.Background(), "trino-1"); err != nil {
+				t.Fatal(err)
+			}
+			if len(*requests) != 1 || (*requests)[0] != tt.want {
+				t.Fatalf("got requests %q, want %q", *requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPath(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "GET " + DefaultListPath},
+		{name: "custom", opts: []Option{WithListPath("/api/public/backends")}, want: "GET /api/public/backends"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := recordingServer(t, "[]")
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if err := client.Authenticate(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(*requests) != 2 || (*requests)[0] != tt.want || (*requests)[1] != tt.want {
+				t.Fatalf("got requests %q, want %q twice", *requests, tt.want)
+			}
+		})
+	}
+}
+
+// backendsJSON is json array of count backends named backend-0, backend-1 and so on.
+func backendsJSON(count int) string {
+	var list strings.Builder
+	list.WriteString("[")
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			list.WriteString(",")
+		}
+		fmt.Fprintf(&list, `{"name":"backend-%d","proxyTo":"http://backend-%d","routingGroup":"adhoc","active":true}`, i, i)
+	}
+	list.WriteString("]")
+	return list.String()
+}
+
+func TestGetAllBackendsLargeList(t *testing.T) {
+	const count = 20000
+	server := staticServer(t, http.StatusOK, "application/json", backendsJSON(count))
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backends, err := client.GetAllBackends(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backends) != count {
+		t.Fatalf("got %d backends, want %d", len(backends), count)
+	}
+	for i, backend := range backends {
+		if want := fmt.Sprintf("backend-%d", i); backend.Name != want || backend.ProxyTo != "http://"+want {
+			t.Fatalf("got backend %v at %d, want %s", backend, i, want)
+		}
+	}
+}
+
+func TestGetAllBackendsLargeListDecodeError(t *testing.T) {
+	// truncated list fails at the very end, only its beginning is kept for error message
+	list := backendsJSON(20000)
+	server := staticServer(t, http.StatusOK, "application/json", list[:len(list)-1])
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetAllBackends(context.Background())
+	if err == nil {
+		t.Fatal("want error")
+	}
+	if !strings.Contains(err.Error(), list[:maxResponseBodyLogSize]) || strings.Contains(err.Error(), list[:maxResponseBodyLogSize+1]) {
+		t.Fatalf("error does not contain exactly first %d bytes of body: %.2000s", maxResponseBodyLogSize, err)
+	}
+}
+
+func TestGetAllBackendsMaxResponseSize(t *testing.T) {
+	body := backendsJSON(100)
+	tests := []struct {
+		name    string
+		limit   int64
+		wantErr bool
+	}{
+		{name: "below limit", limit: int64(len(body)) + 1},
+		{name: "at limit", limit: int64(len(body))},
+		{name: "over limit", limit: int64(len(body)) - 1, wantErr: true},
+		{name: "far over limit", limit: 16, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := staticServer(t, http.StatusOK, "application/json", body)
+			client, err := NewTrinoGatewayClient(server.URL, nil, WithMaxResponseSize(tt.limit))
+			if err != nil {
+				t.Fatal(err)
+			}
+			backends, err := client.GetAllBackends(context.Background())
+			if !tt.wantErr {
+				if err != nil || len(backends) != 100 {
+					t.Fatalf("got %d backends, %v, want 100", len(backends), err)
+				}
+				return
+			}
+			var tooLarge *ResponseTooLargeError
+			if !errors.As(err, &tooLarge) || tooLarge.Limit != tt.limit {
+				t.Fatalf("got %v, want ResponseTooLargeError with limit %d", err, tt.limit)
+			}
+		})
+	}
+}
+
+func TestAcceptHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantAccept string
+	}{
+		{name: "default", wantAccept: "application/json"},
+		{name: "overridden", opts: []Option{WithHeaders(map[string]string{"Accept": "application/vnd.gateway+json"})}, wantAccept: "application/vnd.gateway+json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept")
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if accept != tt.wantAccept {
+				t.Fatalf("got Accept %q, want %q", accept, tt.wantAccept)
+			}
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	server, requests := recordingServer(t, `[]`)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithReadOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mutations := map[string]func() error{
+		"add":        func() error { return client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b"}) },
+		"delete":     func() error { return client.DeleteBackend(context.Background(), "b") },
+		"deactivate": func() error { return client.DeactivateBackend(context.Background(), "b") },
+		"default":    func() error { return client.SetDefaultRoutingGroup(context.Background(), "g") },
+		"replace": func() error {
+			_, err := client.ReplaceAllBackends(context.Background(), nil)
+			return err
+		},
+	}
+	for name, mutate := range mutations {
+		if err := mutate(); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%s: got %v, want ErrReadOnly", name, err)
+		}
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatalf("reads should work in read-only mode: %v", err)
+	}
+	if len(*requests) != 1 {
+		t.Fatalf("gateway got requests %q, want only read", *requests)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if login, password, ok := r.BasicAuth(); !ok || login != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	tests := []struct {
+		name       string
+		password   string
+		wantStatus int
+	}{
+		{name: "valid", password: "secret"},
+		{name: "invalid", password: "wrong", wantStatus: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			client, err := NewTrinoGatewayClient(server.URL, &Auth{Login: "admin", Password: tt.password})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.Authenticate(context.Background())
+			var authErr *AuthError
+			if tt.wantStatus == 0 && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantStatus != 0 && (!errors.As(err, &authErr) || authErr.StatusCode != tt.wantStatus) {
+				t.Fatalf("got %v, want AuthError with status %d", err, tt.wantStatus)
+			}
+			if len(requests) != 1 || requests[0] != "GET "+DefaultListPath {
+				t.Fatalf("got requests %q, want single list request", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// WithRequestCompression gzips request bodies and sets Content-Encoding: gzip.
+// Gateway does not advertise support, so it must be enabled only for gateways
+// or proxies in front of them known to decode such requests.
+func WithRequestCompression() Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.compressRequests = true
+	}
+}
+
+// gzipBody returns compressed body in memory, so request can be rewound for retries.
+func gzipBody(body io.Reader) (*bytes.Reader, error) {
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	if _, err := io.Copy(writer, body); err != nil {
+		return nil, fmt.Errorf("cant compress request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("cant compress request body: %w", err)
+	}
+	return bytes.NewReader(compressed.Bytes()), nil
+}
+
+// gunzipForTrace returns decompressed body for logging, or body as is if it is not valid gzip.
+func gunzipForTrace(body []byte) []byte {
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return body
+	}
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxTraceBodySize))
+	if err != nil {
+		return body
+	}
+	return decompressed
+}
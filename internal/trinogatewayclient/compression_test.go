@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type compressedRequest struct {
+	method          string
+	contentEncoding string
+	backend         Backend
+}
+
+// gzipServer decodes request bodies like gateway behind decompressing proxy would.
+// First request fails with 503 if failFirst is set.
+func gzipServer(t *testing.T, failFirst bool, requests *[]compressedRequest) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := compressedRequest{method: r.Method, contentEncoding: r.Header.Get("Content-Encoding")}
+		var body io.Reader = r.Body
+		if request.contentEncoding == "gzip" {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("cant decompress request: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = reader
+		}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(body).Decode(&request.backend); err != nil {
+				t.Errorf("cant decode request: %v", err)
+			}
+		}
+		*requests = append(*requests, request)
+		if failFirst && len(*requests) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRequestCompression(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantEncoding string
+	}{
+		{name: "enabled", opts: []Option{WithRequestCompression()}, wantEncoding: "gzip"},
+		{name: "disabled", wantEncoding: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []compressedRequest
+			server := gzipServer(t, false, &requests)
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080"}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(requests) != 2 {
+				t.Fatalf("got %d requests, want 2", len(requests))
+			}
+			if requests[0].contentEncoding != tt.wantEncoding || requests[0].backend.Name != "trino-1" {
+				t.Fatalf("got upsert %+v, want encoding %q", requests[0], tt.wantEncoding)
+			}
+			if requests[1].contentEncoding != "" {
+				t.Fatalf("request without body has encoding %q", requests[1].contentEncoding)
+			}
+		})
+	}
+}
+
+func TestRequestCompressionRetry(t *testing.T) {
+	var requests []compressedRequest
+	server := gzipServer(t, true, &requests)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRequestCompression(), WithRetry(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "trino-1", ProxyTo: "http://trino-1:8080"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(requests))
+	}
+	if requests[1].backend != requests[0].backend || requests[1].contentEncoding != "gzip" {
+		t.Fatalf("retry sent %+v, first attempt sent %+v", requests[1], requests[0])
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// maxIfMatchAttempts bounds writes rejected by 412 while backend itself is unchanged.
+const maxIfMatchAttempts = 3
+
+// ConflictError is returned when backend was changed by somebody else since it was read.
+type ConflictError struct {
+	Name string
+	// CurrentVersion is empty if backend was deleted
+	CurrentVersion string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("backend %s was changed concurrently", e.Name)
+}
+
+// BackendVersion identifies backend contents, gateway has no own backend versions.
+// It is empty for nil backend.
+func BackendVersion(backend *Backend) string {
+	if backend == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(backend)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// UpdateBackendIfMatch updates backend only if its current version equals expectedVersion,
+// otherwise ConflictError is returned. Gateway has no backend versions, so backend is read again and compared,
+// then written with If-Match of backends list ETag of that read. Gateways answering 412 to stale If-Match
+// make the write conditional, on 412 backend is compared again, as list ETag changes with any backend.
+// For gateways without ETags, upstream gateway included, the check is best-effort: only callers of this client
+// are serialized, changes made by others between the read and the write are overwritten.
+// With ETags the read is cheap, it is answered by 304 Not Modified.
+func (tg *trinoGatewayClientHttpImpl) UpdateBackendIfMatch(ctx context.Context, backend *Backend, expectedVersion string) (err error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return err
+	}
+	defer func() { tg.observeMutation(OperationAddOrUpdateBackend, backend.Name, err) }()
+	defer tg.backendLocks.Lock(backend.Name)()
+	defer tg.backendsCache.invalidate()
+
+	for attempt := 0; ; attempt++ {
+		current, err := tg.GetBackend(ctx, backend.Name)
+		if err != nil && !errors.Is(err, ErrBackendNotFound) {
+			return err
+		}
+		currentVersion := BackendVersion(current)
+		if currentVersion != expectedVersion || attempt == maxIfMatchAttempts {
+			return &ConflictError{Name: backend.Name, CurrentVersion: currentVersion}
+		}
+		err = tg.postBackend(ctx, backend, tg.backendsCache.getEtag())
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusPreconditionFailed {
+			return err
+		}
+		tg.backendsCache.invalidate()
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient/gatewaytest"
+)
+
+func TestUpdateBackendIfMatch(t *testing.T) {
+	original := Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "adhoc", Active: true}
+	tests := []struct {
+		name string
+		// change is made by somebody else after version was taken
+		change       func(gateway *gatewaytest.Gateway)
+		wantConflict bool
+		wantVersion  func(gateway *gatewaytest.Gateway) string
+	}{
+		{
+			name: "unchanged",
+		},
+		{
+			name: "changed",
+			change: func(gateway *gatewaytest.Gateway) {
+				changed := original
+				changed.Active = false
+				gateway.Set(changed)
+			},
+			wantConflict: true,
+			wantVersion: func(gateway *gatewaytest.Gateway) string {
+				current := Backend{}
+				gateway.Get("b", &current)
+				return BackendVersion(&current)
+			},
+		},
+		{
+			name: "deleted",
+			change: func(gateway *gatewaytest.Gateway) {
+				gateway.Delete("b")
+			},
+			wantConflict: true,
+			wantVersion:  func(*gatewaytest.Gateway) string { return "" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, server := newFakeGateway(t, original)
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := client.GetBackend(context.Background(), "b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			version := BackendVersion(read)
+			if tt.change != nil {
+				tt.change(gateway)
+			}
+
+			update := original
+			update.RoutingGroup = "etl"
+			err = client.UpdateBackendIfMatch(context.Background(), &update, version)
+			var conflictErr *ConflictError
+			if got := errors.As(err, &conflictErr); got != tt.wantConflict {
+				t.Fatalf("got error %v, want conflict: %v", err, tt.wantConflict)
+			}
+			current := Backend{}
+			gateway.Get("b", &current)
+			if !tt.wantConflict {
+				if current.RoutingGroup != "etl" {
+					t.Fatalf("backend was not updated: %+v", current)
+				}
+				return
+			}
+			if conflictErr.CurrentVersion != tt.wantVersion(gateway) {
+				t.Fatalf("conflict reports version %q, want %q", conflictErr.CurrentVersion, tt.wantVersion(gateway))
+			}
+			if current.RoutingGroup == "etl" {
+				t.Fatal("conflicting update was written")
+			}
+		})
+	}
+}
+
+// etagGateway serves backends list with ETag changing on every write and rejects writes with stale If-Match.
+type etagGateway struct {
+	mu       sync.Mutex
+	version  int
+	backends map[string]Backend
+	ifMatch  []string
+	// beforeWrite is called once before the first write is checked, as if somebody else wrote first
+	beforeWrite func(g *etagGateway)
+}
+
+func (g *etagGateway) etag() string {
+	return fmt.Sprintf(`"v%d"`, g.version)
+}
+
+func (g *etagGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if r.Method == http.MethodGet {
+		if r.Header.Get("If-None-Match") == g.etag() {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		backends := []Backend{}
+		for _, backend := range g.backends {
+			backends = append(backends, backend)
+		}
+		w.Header().Set("ETag", g.etag())
+		_ = json.NewEncoder(w).Encode(backends)
+		return
+	}
+	if g.beforeWrite != nil {
+		g.beforeWrite(g)
+		g.beforeWrite = nil
+	}
+	g.ifMatch = append(g.ifMatch, r.Header.Get("If-Match"))
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != g.etag() {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	backend := Backend{}
+	_ = json.NewDecoder(r.Body).Decode(&backend)
+	g.backends[backend.Name] = backend
+	g.version++
+}
+
+func TestUpdateBackendIfMatchHeader(t *testing.T) {
+	original := Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "adhoc", Active: true}
+	other := Backend{Name: "other", ProxyTo: "http://other", RoutingGroup: "adhoc", Active: true}
+	tests := []struct {
+		name         string
+		beforeWrite  func(g *etagGateway)
+		wantIfMatch  []string
+		wantConflict bool
+	}{
+		{
+			name:        "unchanged",
+			wantIfMatch: []string{`"v0"`},
+		},
+		{
+			name: "other backend changed",
+			beforeWrite: func(g *etagGateway) {
+				changed := other
+				changed.Active = false
+				g.backends[other.Name] = changed
+				g.version++
+			},
+			wantIfMatch: []string{`"v0"`, `"v1"`},
+		},
+		{
+			name: "backend changed",
+			beforeWrite: func(g *etagGateway) {
+				changed := original
+				changed.Active = false
+				g.backends[original.Name] = changed
+				g.version++
+			},
+			wantIfMatch:  []string{`"v0"`},
+			wantConflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway := &etagGateway{
+				backends:    map[string]Backend{original.Name: original, other.Name: other},
+				beforeWrite: tt.beforeWrite,
+			}
+			server := httptest.NewServer(gateway)
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			read, err := client.GetBackend(context.Background(), "b")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			update := original
+			update.RoutingGroup = "etl"
+			err = client.UpdateBackendIfMatch(context.Background(), &update, BackendVersion(read))
+			var conflictErr *ConflictError
+			if got := errors.As(err, &conflictErr); got != tt.wantConflict {
+				t.Fatalf("got error %v, want conflict: %v", err, tt.wantConflict)
+			}
+			if fmt.Sprint(gateway.ifMatch) != fmt.Sprint(tt.wantIfMatch) {
+				t.Fatalf("gateway got If-Match %q, want %q", gateway.ifMatch, tt.wantIfMatch)
+			}
+			current := gateway.backends["b"]
+			if tt.wantConflict {
+				if current.RoutingGroup == "etl" {
+					t.Fatal("conflicting update was written")
+				}
+				if conflictErr.CurrentVersion != BackendVersion(&current) {
+					t.Fatalf("conflict reports version %q, want current one", conflictErr.CurrentVersion)
+				}
+				return
+			}
+			if current.RoutingGroup != "etl" {
+				t.Fatalf("backend was not updated: %+v", current)
+			}
+		})
+	}
+}
+
+func TestUpdateBackendIfMatchWithoutEtag(t *testing.T) {
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"name":"b","proxyTo":"http://b","routingGroup":"adhoc","active":true}]`))
+			return
+		}
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := client.GetBackend(context.Background(), "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateBackendIfMatch(context.Background(), read, BackendVersion(read)); err != nil {
+		t.Fatal(err)
+	}
+	if len(ifMatch) != 1 || ifMatch[0] != "" {
+		t.Fatalf("gateway without ETags got If-Match %q", ifMatch)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ContentTypeJSON = "json"
+	ContentTypeYAML = "yaml"
+
+	yamlMediaType = "application/yaml"
+)
+
+// WithContentType sets encoding of backend entities, ContentTypeYAML is for gateway forks speaking yaml.
+// Other gateway endpoints are always json.
+func WithContentType(contentType string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.contentType = contentType
+	}
+}
+
+// WithOmitEmptyFields omits empty optional fields of sent backends instead of sending them as empty strings.
+// Some gateways treat absent externalUrl differently from empty one. Unset weight is never sent.
+func WithOmitEmptyFields() Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.omitEmptyFields = true
+	}
+}
+
+// backendOmitEmpty is Backend with omitempty optional fields, Backend is converted to it.
+type backendOmitEmpty struct {
+	Name         string  `json:"name" yaml:"name"`
+	ProxyTo      string  `json:"proxyTo" yaml:"proxyTo"`
+	RoutingGroup string  `json:"routingGroup" yaml:"routingGroup"`
+	Active       bool    `json:"active" yaml:"active"`
+	ExternalUrl  string  `json:"externalUrl,omitempty" yaml:"externalUrl,omitempty"`
+	Weight       *Number `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+func (tg *trinoGatewayClientHttpImpl) marshalBackend(backend *Backend) ([]byte, error) {
+	var value interface{} = backend
+	if tg.omitEmptyFields {
+		value = backendOmitEmpty(*backend)
+	}
+	if tg.contentType == ContentTypeYAML {
+		return yaml.Marshal(value)
+	}
+	return json.Marshal(value)
+}
+
+// setEntityHeaders adjusts content negotiation of backend entity request.
+// Json requests keep headers set by newRequest.
+func (tg *trinoGatewayClientHttpImpl) setEntityHeaders(request *http.Request) {
+	if tg.contentType != ContentTypeYAML {
+		return
+	}
+	if request.Method == http.MethodGet {
+		request.Header.Set("Accept", yamlMediaType)
+		return
+	}
+	request.Header.Set("Content-Type", yamlMediaType)
+}
+
+// decodeBackends decodes backends list, unknown fields are error with WithStrictJSON.
+func (tg *trinoGatewayClientHttpImpl) decodeBackends(reader io.Reader) ([]*Backend, error) {
+	backends := []*Backend{}
+	if tg.contentType == ContentTypeYAML {
+		decoder := yaml.NewDecoder(reader)
+		decoder.KnownFields(tg.strictJSON)
+		// empty document is empty list
+		if err := decoder.Decode(&backends); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return backends, nil
+	}
+	decoder := json.NewDecoder(reader)
+	if tg.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(&backends); err != nil {
+		return nil, err
+	}
+	return backends, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestContentTypeYAML(t *testing.T) {
+	var posted Backend
+	var accept, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			accept = r.Header.Get("Accept")
+			w.Header().Set("Content-Type", yamlMediaType)
+			_, _ = w.Write([]byte("- name: trino-1\n  proxyTo: http://trino-1:8080\n  routingGroup: adhoc\n  active: true\n"))
+			return
+		}
+		contentType = r.Header.Get("Content-Type")
+		if err := yaml.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Errorf("cant decode yaml request: %v", err)
+		}
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithContentType(ContentTypeYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backends, err := client.GetAllBackends(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backends) != 1 || backends[0].Name != "trino-1" || backends[0].ProxyTo != "http://trino-1:8080" || !backends[0].Active {
+		t.Fatalf("got backends %v", backends)
+	}
+	if accept != yamlMediaType {
+		t.Fatalf("got Accept %q, want %q", accept, yamlMediaType)
+	}
+
+	backend := &Backend{Name: "trino-2", ProxyTo: "http://trino-2:8080", RoutingGroup: "etl"}
+	if err := client.AddOrUpdateBackend(context.Background(), backend); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != yamlMediaType {
+		t.Fatalf("got Content-Type %q, want %q", contentType, yamlMediaType)
+	}
+	if !posted.Equal(backend) {
+		t.Fatalf("gateway got %+v, want %+v", posted, *backend)
+	}
+}
+
+func TestContentTypeYAMLDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		opts      []Option
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "empty document", body: ""},
+		{name: "empty list", body: "[]\n"},
+		{name: "unknown field", body: "- name: trino-1\n  labels: {}\n", wantCount: 1},
+		{name: "strict unknown field", body: "- name: trino-1\n  labels: {}\n", opts: []Option{WithStrictJSON()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := staticServer(t, http.StatusOK, yamlMediaType, tt.body)
+			client, err := NewTrinoGatewayClient(server.URL, nil, append(tt.opts, WithContentType(ContentTypeYAML))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backends, err := client.GetAllBackends(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(backends) != tt.wantCount {
+				t.Fatalf("got %d backends, want %d", len(backends), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestOmitEmptyFields(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []Option
+		wantExternalUrl bool
+	}{
+		{name: "default", wantExternalUrl: true},
+		{name: "omit empty", opts: []Option{WithOmitEmptyFields()}},
+		{name: "omit empty yaml", opts: []Option{WithOmitEmptyFields(), WithContentType(ContentTypeYAML)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// yaml is superset of json, so both are decoded by yaml decoder
+				if err := yaml.NewDecoder(r.Body).Decode(&sent); err != nil {
+					t.Errorf("cant decode request: %v", err)
+				}
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "g"}); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := sent["externalUrl"]; ok != tt.wantExternalUrl {
+				t.Fatalf("externalUrl sent: %v, want %v: %v", ok, tt.wantExternalUrl, sent)
+			}
+			for _, field := range []string{"name", "proxyTo", "routingGroup", "active"} {
+				if _, ok := sent[field]; !ok {
+					t.Fatalf("required field %s is not sent: %v", field, sent)
+				}
+			}
+			if _, ok := sent["weight"]; ok {
+				t.Fatalf("unset weight is sent: %v", sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetBackendDefaults(t *testing.T) {
+	server := staticServer(t, http.StatusOK, "application/json", `{"routingGroup":"adhoc","externalUrlTemplate":"https://{name}.example.com"}`)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults, err := client.GetBackendDefaults(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BackendDefaults{RoutingGroup: "adhoc", ExternalUrlTemplate: "https://{name}.example.com"}
+	if defaults == nil || *defaults != want {
+		t.Fatalf("got defaults %v, want %v", defaults, want)
+	}
+}
+
+func TestGetBackendDefaultsNotExposed(t *testing.T) {
+	server := staticServer(t, http.StatusNotFound, "text/plain", "not found")
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults, err := client.GetBackendDefaults(context.Background())
+	if err != nil || defaults != nil {
+		t.Fatalf("got %v, %v, want no defaults", defaults, err)
+	}
+}
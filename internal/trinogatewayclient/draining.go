@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+type backendStateResponse struct {
+	Name  string           `json:"name"`
+	State map[string]int64 `json:"state"`
+}
+
+// GetBackendInFlightQueries returns number of running and queued queries of backend
+// as reported by gateway public api. Returns nil if gateway does not expose backend state.
+func (tg *trinoGatewayClientHttpImpl) GetBackendInFlightQueries(ctx context.Context, name string) (*int64, error) {
+	request, err := tg.newRequest(ctx, http.MethodGet, "/api/public/backends/"+url.PathEscape(name)+"/state", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cant read response body")
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if response.StatusCode != 200 {
+		return nil, badResponseError(response, responseBody)
+	}
+
+	state := &backendStateResponse{}
+	if err := json.Unmarshal(responseBody, state); err != nil {
+		return nil, fmt.Errorf(
+			"cant unmarshal response: %w, body: %s",
+			err,
+			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
+		)
+	}
+	if state.State == nil {
+		return nil, nil
+	}
+	inFlight := state.State["RUNNING"] + state.State["QUEUED"]
+	return &inFlight, nil
+}
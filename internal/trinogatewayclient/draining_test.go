@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetBackendInFlightQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    *int64
+		wantErr bool
+	}{
+		{name: "running and queued", status: http.StatusOK, body: `{"name":"team/trino-1","state":{"RUNNING":3,"QUEUED":2,"FINISHED":10}}`, want: int64Ptr(5)},
+		{name: "idle", status: http.StatusOK, body: `{"name":"team/trino-1","state":{}}`, want: int64Ptr(0)},
+		{name: "no state", status: http.StatusOK, body: `{"name":"team/trino-1"}`},
+		{name: "not exposed", status: http.StatusNotFound},
+		{name: "gateway error", status: http.StatusInternalServerError, wantErr: true},
+		{name: "invalid body", status: http.StatusOK, body: `running`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.EscapedPath()
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := client.GetBackendInFlightQueries(context.Background(), "team/trino-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if path != "/api/public/backends/team%2Ftrino-1/state" {
+				t.Fatalf("got path %s", path)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
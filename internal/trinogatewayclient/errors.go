@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnly is returned by mutating methods of client created with WithReadOnly.
+var ErrReadOnly = errors.New("provider is in read-only mode, gateway changes are not allowed")
+
+// ResponseTooLargeError is returned when gateway response exceeds limit set by WithMaxResponseSize.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("gateway response exceeds %d bytes, raise provider max_response_size if gateway really has that many backends", e.Limit)
+}
+
+// AuthError is returned when gateway rejects request with 401 or 403.
+type AuthError struct {
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	if e.StatusCode == http.StatusForbidden {
+		return fmt.Sprintf("permission denied (http %d): credentials are valid, but not allowed to perform this operation, check gateway user permissions", e.StatusCode)
+	}
+	return fmt.Sprintf("authentication failed (http %d): check provider credentials", e.StatusCode)
+}
+
+// StatusError is returned when gateway responds with unexpected http code.
+type StatusError struct {
+	StatusCode int
+	message    string
+}
+
+func (e *StatusError) Error() string {
+	return e.message
+}
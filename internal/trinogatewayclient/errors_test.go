@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// staticServer answers every request with status, content type and body.
+func staticServer(t *testing.T, status int, contentType string, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBadResponseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+		notWant     string
+	}{
+		{
+			name:        "html page",
+			contentType: "text/html; charset=utf-8",
+			body:        "<html><body><h1>500 Internal Server Error</h1></body></html>",
+			want:        "HTML error page",
+			notWant:     "<html>",
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			body:        "backend name is invalid",
+			want:        "backend name is invalid",
+		},
+		{
+			name:        "long body is truncated",
+			contentType: "text/plain",
+			body:        strings.Repeat("x", maxResponseBodyLogSize*2),
+			want:        strings.Repeat("x", maxResponseBodyLogSize),
+			notWant:     strings.Repeat("x", maxResponseBodyLogSize+1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := staticServer(t, http.StatusInternalServerError, tt.contentType, tt.body)
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.GetAllBackends(context.Background())
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+				t.Fatalf("got %v, want StatusError", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error %q does not contain %q", err, tt.want)
+			}
+			if tt.notWant != "" && strings.Contains(err.Error(), tt.notWant) {
+				t.Fatalf("error %q contains %q", err, tt.notWant)
+			}
+		})
+	}
+}
+
+func TestAuthError(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{status: http.StatusUnauthorized, want: "authentication failed"},
+		{status: http.StatusForbidden, want: "permission denied"},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := staticServer(t, tt.status, "text/html", "<html><body>denied</body></html>")
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.GetAllBackends(context.Background())
+			var authErr *AuthError
+			if !errors.As(err, &authErr) || authErr.StatusCode != tt.status {
+				t.Fatalf("got %v, want AuthError", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error %q does not contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ExportBackends returns all backends as indented json sorted by name,
+// so the same backend set always produces the same output.
+func (tg *trinoGatewayClientHttpImpl) ExportBackends(ctx context.Context) ([]byte, error) {
+	backends, err := tg.GetAllBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].Name < backends[j].Name
+	})
+	result, err := json.MarshalIndent(backends, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cant marshal backends: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestExportBackendsIsStable(t *testing.T) {
+	lists := []string{
+		`[{"name":"b","proxyTo":"http://b","routingGroup":"etl","active":true},{"name":"a","proxyTo":"http://a","routingGroup":"adhoc","active":false}]`,
+		`[{"name":"a","proxyTo":"http://a","routingGroup":"adhoc","active":false},{"name":"b","proxyTo":"http://b","routingGroup":"etl","active":true}]`,
+	}
+	var exports [][]byte
+	for _, list := range lists {
+		client, err := NewTrinoGatewayClient(staticServer(t, http.StatusOK, "application/json", list).URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		export, err := client.ExportBackends(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		exports = append(exports, export)
+	}
+	if !bytes.Equal(exports[0], exports[1]) {
+		t.Fatalf("exports differ:\n%s\n%s", exports[0], exports[1])
+	}
+	backends := []Backend{}
+	if err := json.Unmarshal(exports[0], &backends); err != nil {
+		t.Fatal(err)
+	}
+	if len(backends) != 2 || backends[0].Name != "a" || backends[1].Name != "b" {
+		t.Fatalf("got exported backends %v, want sorted by name", backends)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paragor/terraform-provider-trinogateway/internal/trinogatewayclient/gatewaytest"
+)
+
+// newFakeGateway starts in-memory gateway with backends.
+func newFakeGateway(t *testing.T, backends ...Backend) (*gatewaytest.Gateway, *httptest.Server) {
+	gateway, server := gatewaytest.New(t)
+	for _, backend := range backends {
+		gateway.Set(backend)
+	}
+	return gateway, server
+}
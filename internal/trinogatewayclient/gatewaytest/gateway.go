@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package gatewaytest provides in-memory trino gateway for tests of client and provider.
+package gatewaytest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// paths are defaults of trinogatewayclient. It is not imported, so tests inside it can use Gateway.
+const (
+	listPath                = "/entity/GATEWAY_BACKEND"
+	updatePath              = "/entity"
+	deletePath              = "/gateway/backend/modify/delete"
+	deactivatePath          = "/gateway/backend/deactivate/"
+	defaultRoutingGroupPath = "/gateway/routingGroup/default"
+)
+
+// Gateway keeps backends and default routing group in memory and serves their endpoints.
+// Backends are kept as json sent by client, so any value marshaled to gateway backend json can be used.
+type Gateway struct {
+	mu       sync.Mutex
+	backends map[string]json.RawMessage
+	// defaultRoutingGroup is reported as not found if empty
+	defaultRoutingGroup string
+	// failWrites makes writes of these backends fail with 500
+	failWrites map[string]bool
+	writes     []string
+}
+
+// New starts gateway with backends, server is closed on test cleanup.
+func New(t *testing.T, backends ...any) (*Gateway, *httptest.Server) {
+	t.Helper()
+	gateway := &Gateway{backends: map[string]json.RawMessage{}, failWrites: map[string]bool{}}
+	for _, backend := range backends {
+		gateway.Set(backend)
+	}
+	server := httptest.NewServer(gateway)
+	t.Cleanup(server.Close)
+	return gateway, server
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == listPath:
+		_ = json.NewEncoder(w).Encode(g.list())
+	case r.Method == http.MethodPost && r.URL.Path == updatePath:
+		name, err := backendName(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		g.writes = append(g.writes, "update "+name)
+		if g.failWrites[name] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		g.backends[name] = body
+	case r.Method == http.MethodGet && r.URL.Path == defaultRoutingGroupPath:
+		if g.defaultRoutingGroup == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"routingGroup": g.defaultRoutingGroup})
+	case r.Method == http.MethodPost && r.URL.Path == defaultRoutingGroupPath:
+		request := map[string]string{}
+		if err := json.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		g.writes = append(g.writes, "set default "+request["routingGroup"])
+		g.defaultRoutingGroup = request["routingGroup"]
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, deactivatePath):
+		name := strings.TrimPrefix(r.URL.Path, deactivatePath)
+		backend, ok := g.backends[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		g.writes = append(g.writes, "deactivate "+name)
+		if g.failWrites[name] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fields := map[string]json.RawMessage{}
+		_ = json.Unmarshal(backend, &fields)
+		fields["active"] = json.RawMessage("false")
+		g.backends[name], _ = json.Marshal(fields)
+	case r.Method == http.MethodPost && r.URL.Path == deletePath:
+		name := string(body)
+		g.writes = append(g.writes, "delete "+name)
+		if g.failWrites[name] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		delete(g.backends, name)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// list returns backends sorted by name.
+func (g *Gateway) list() []json.RawMessage {
+	names := make([]string, 0, len(g.backends))
+	for name := range g.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	backends := make([]json.RawMessage, 0, len(names))
+	for _, name := range names {
+		backends = append(backends, g.backends[name])
+	}
+	return backends
+}
+
+func backendName(backend []byte) (string, error) {
+	named := struct {
+		Name string `json:"name"`
+	}{}
+	err := json.Unmarshal(backend, &named)
+	return named.Name, err
+}
+
+// Set changes backend as if someone else did it. It panics if backend cant be marshaled.
+func (g *Gateway) Set(backend any) {
+	raw, err := json.Marshal(backend)
+	if err != nil {
+		panic(err)
+	}
+	name, err := backendName(raw)
+	if err != nil {
+		panic(err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.backends[name] = raw
+}
+
+// Get unmarshals backend into v, it returns false if there is no such backend.
+func (g *Gateway) Get(name string, v any) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	backend, ok := g.backends[name]
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(backend, v); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// Delete deletes backend as if someone else did it.
+func (g *Gateway) Delete(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.backends, name)
+}
+
+// Names returns sorted names of backends.
+func (g *Gateway) Names() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := []string{}
+	for name := range g.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDefaultRoutingGroup changes default routing group as if someone else did it.
+func (g *Gateway) SetDefaultRoutingGroup(routingGroup string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.defaultRoutingGroup = routingGroup
+}
+
+// FailWrites makes updates, deactivations and deletes of backends fail with 500.
+func (g *Gateway) FailWrites(names ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, name := range names {
+		g.failWrites[name] = true
+	}
+}
+
+// Writes returns log of writes like "update NAME", "deactivate NAME", "delete NAME" and "set default GROUP".
+func (g *Gateway) Writes() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return slices.Clone(g.writes)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const backendProbeTimeout = 10 * time.Second
+
+// BackendHealth is result of single probe, failed probe is unhealthy result with Error set.
+type BackendHealth struct {
+	Healthy   bool
+	Latency   time.Duration
+	CheckedAt time.Time
+	Error     string
+}
+
+type trinoInfoResponse struct {
+	Starting bool `json:"starting"`
+}
+
+// ProbeBackend resolves backend by name on gateway and probes trino info endpoint of its proxy_to.
+// Gateway has no on-demand health check endpoint, so probe is sent from provider host directly to backend,
+// not through gateway. It uses provider transport and proxy, but no gateway credentials, headers, middlewares,
+// retries, circuit breaker or observer, which are meant for gateway. Probe is canceled by Close.
+func (tg *trinoGatewayClientHttpImpl) ProbeBackend(ctx context.Context, name string) (*BackendHealth, error) {
+	backend, err := tg.GetBackend(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, backendProbeTimeout)
+	defer cancel()
+
+	health := &BackendHealth{CheckedAt: time.Now()}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(backend.ProxyTo, "/")+"/v1/info", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cant create request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	request, release := tg.withCloseContext(request)
+	defer release()
+	response, err := tg.probeClient.Do(request)
+	health.Latency = time.Since(health.CheckedAt)
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxResponseBodyLogSize))
+	if err != nil {
+		health.Error = fmt.Sprintf("cant read response body: %s", err)
+		return health, nil
+	}
+	if response.StatusCode != 200 {
+		health.Error = fmt.Sprintf("unexpected http code %d, body: %s", response.StatusCode, responseBody)
+		return health, nil
+	}
+	info := &trinoInfoResponse{}
+	if err := json.Unmarshal(responseBody, info); err != nil {
+		health.Error = fmt.Sprintf("cant unmarshal response: %s, body: %s", err, responseBody)
+		return health, nil
+	}
+	if info.Starting {
+		health.Error = "backend is starting"
+		return health, nil
+	}
+	health.Healthy = true
+	return health, nil
+}
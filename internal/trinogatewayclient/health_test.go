@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbeBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantHealthy bool
+	}{
+		{name: "healthy", status: http.StatusOK, body: `{"starting":false}`, wantHealthy: true},
+		{name: "starting", status: http.StatusOK, body: `{"starting":true}`, wantHealthy: false},
+		{name: "error response", status: http.StatusInternalServerError, body: "boom", wantHealthy: false},
+		{name: "invalid body", status: http.StatusOK, body: "not json", wantHealthy: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/info" {
+					t.Errorf("unexpected backend request %s", r.URL)
+				}
+				if r.Header.Get("Authorization") != "" {
+					t.Errorf("gateway credentials sent to backend")
+				}
+				if r.Header.Get("X-Gateway-Tenant") != "" || r.Header.Get("X-Middlewares") != "" || r.Header.Get("X-Run-Id") != "" {
+					t.Errorf("gateway headers sent to backend: %v", r.Header)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer backend.Close()
+			gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, `[{"name":"b","proxyTo":%q,"routingGroup":"g","active":true}]`, backend.URL+"/")
+			}))
+			defer gateway.Close()
+
+			client, err := NewTrinoGatewayClient(gateway.URL, &Auth{Login: "admin", Password: "secret"},
+				WithHeaders(map[string]string{"X-Gateway-Tenant": "analytics"}),
+				WithRoundTripper(headerMiddleware("gateway")),
+				WithRunIdHeader("X-Run-Id"),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			health, err := client.ProbeBackend(context.Background(), "b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if health.Healthy != tt.wantHealthy {
+				t.Fatalf("healthy is %v, want %v, error: %s", health.Healthy, tt.wantHealthy, health.Error)
+			}
+			if !tt.wantHealthy && health.Error == "" {
+				t.Fatal("unhealthy result has no error")
+			}
+		})
+	}
+}
+
+func TestProbeBackendNotFound(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer gateway.Close()
+	client, err := NewTrinoGatewayClient(gateway.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ProbeBackend(context.Background(), "b"); err == nil {
+		t.Fatal("want error")
+	}
+}
+
+func TestProbeBackendCanceledByClose(t *testing.T) {
+	started := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer backend.Close()
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[{"name":"b","proxyTo":%q,"routingGroup":"g","active":true}]`, backend.URL)
+	}))
+	defer gateway.Close()
+	client, err := NewTrinoGatewayClient(gateway.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		<-started
+		_ = client.Close()
+	}()
+	start := time.Now()
+	health, err := client.ProbeBackend(context.Background(), "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed >= 5*time.Second {
+		t.Fatalf("returned after %s, probe was not canceled", elapsed)
+	}
+	if health.Healthy || health.Error == "" {
+		t.Fatalf("got %+v, want unhealthy result", *health)
+	}
+}
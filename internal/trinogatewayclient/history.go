@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// BackendStateRecord is past state of backend recorded by gateway.
+type BackendStateRecord struct {
+	Time         time.Time `json:"time"`
+	Active       bool      `json:"active"`
+	ProxyTo      string    `json:"proxyTo"`
+	RoutingGroup string    `json:"routingGroup"`
+	ExternalUrl  string    `json:"externalUrl"`
+}
+
+// GetBackendHistory returns past states of backend ordered from oldest.
+// Upstream gateway keeps no history, for it and other gateways without history endpoint
+// (404, 405 or 501 response) empty list is returned.
+func (tg *trinoGatewayClientHttpImpl) GetBackendHistory(ctx context.Context, name string) ([]*BackendStateRecord, error) {
+	request, err := tg.newRequest(ctx, http.MethodGet, "/api/public/backends/"+url.PathEscape(name)+"/history", nil)
+	if err != nil {
+		return nil, fmt.Errorf("cant create request: %w", err)
+	}
+
+	response, err := tg.do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cant send request: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, tg.maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("cant read response body")
+	}
+	if int64(len(responseBody)) > tg.maxResponseSize {
+		return nil, &ResponseTooLargeError{Limit: tg.maxResponseSize}
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return []*BackendStateRecord{}, nil
+	default:
+		return nil, badResponseError(response, responseBody)
+	}
+
+	history := []*BackendStateRecord{}
+	if err := json.Unmarshal(responseBody, &history); err != nil {
+		return nil, fmt.Errorf(
+			"cant unmarshal response: %w, body: %s",
+			err,
+			responseBody[:min(len(responseBody), maxResponseBodyLogSize)],
+		)
+	}
+	slices.SortStableFunc(history, func(a, b *BackendStateRecord) int {
+		return a.Time.Compare(b.Time)
+	})
+	return history, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetBackendHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantProxyTo []string
+		wantErr     bool
+	}{
+		{
+			name:   "sorted from oldest",
+			status: http.StatusOK,
+			body: `[
+				{"time":"2026-03-02T10:00:00Z","active":true,"proxyTo":"http://trino-1:8081"},
+				{"time":"2026-03-01T10:00:00Z","active":false,"proxyTo":"http://trino-1:8080"}
+			]`,
+			wantProxyTo: []string{"http://trino-1:8080", "http://trino-1:8081"},
+		},
+		{name: "no history endpoint", status: http.StatusNotFound, wantProxyTo: []string{}},
+		{name: "method not allowed", status: http.StatusMethodNotAllowed, wantProxyTo: []string{}},
+		{name: "not implemented", status: http.StatusNotImplemented, wantProxyTo: []string{}},
+		{name: "gateway error", status: http.StatusInternalServerError, wantErr: true},
+		{name: "invalid body", status: http.StatusOK, body: `{}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.EscapedPath()
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			history, err := client.GetBackendHistory(context.Background(), "team/trino-1")
+			if path != "/api/public/backends/team%2Ftrino-1/history" {
+				t.Fatalf("got path %s", path)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(history) != len(tt.wantProxyTo) {
+				t.Fatalf("got %d records, want %d", len(history), len(tt.wantProxyTo))
+			}
+			for i, record := range history {
+				if record.ProxyTo != tt.wantProxyTo[i] {
+					t.Fatalf("got record %+v at %d, want proxy_to %s", *record, i, tt.wantProxyTo[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetBackendHistoryTooLarge(t *testing.T) {
+	server := staticServer(t, http.StatusOK, "application/json", `[{"time":"2026-03-01T10:00:00Z","proxyTo":"http://trino-1:8080"}]`)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithMaxResponseSize(16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.GetBackendHistory(context.Background(), "trino-1")
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 16 {
+		t.Fatalf("got %v, want ResponseTooLargeError with limit 16", err)
+	}
+}
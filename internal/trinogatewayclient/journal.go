@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	OperationAddOrUpdateBackend     = "add_or_update_backend"
+	OperationDeleteBackend          = "delete_backend"
+	OperationDeactivateBackend      = "deactivate_backend"
+	OperationSetDefaultRoutingGroup = "set_default_routing_group"
+)
+
+// MutationObserver may be additionally implemented by RequestObserver
+// to receive outcome of every gateway mutation.
+// target is backend name or routing group, err is nil on success.
+type MutationObserver interface {
+	ObserveMutation(operation string, target string, err error)
+}
+
+func (tg *trinoGatewayClientHttpImpl) observeMutation(operation string, target string, err error) {
+	if mutationObserver, ok := tg.observer.(MutationObserver); ok {
+		mutationObserver.ObserveMutation(operation, target, err)
+	}
+}
+
+// JournalEntry is outcome of single mutation.
+type JournalEntry struct {
+	Time      time.Time
+	Operation string
+	Target    string
+	Err       error
+}
+
+// Journal is observer recording outcome of every mutation,
+// so partially applied changes can be reported after failure.
+type Journal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+}
+
+var _ RequestObserver = &Journal{}
+var _ MutationObserver = &Journal{}
+
+func (j *Journal) ObserveRequest(string, string, int, time.Duration) {}
+
+func (j *Journal) ObserveMutation(operation string, target string, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = append(j.entries, JournalEntry{
+		Time:      time.Now(),
+		Operation: operation,
+		Target:    target,
+		Err:       err,
+	})
+}
+
+// Entries returns recorded mutations in order they were finished.
+func (j *Journal) Entries() []JournalEntry {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]JournalEntry(nil), j.entries...)
+}
+
+// Summary describes recorded mutations one per line, empty if there were none.
+func (j *Journal) Summary() string {
+	var lines []string
+	for _, entry := range j.Entries() {
+		outcome := "ok"
+		if entry.Err != nil {
+			outcome = fmt.Sprintf("failed: %s", entry.Err)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", entry.Operation, entry.Target, outcome))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	gateway, server := newFakeGateway(t, Backend{Name: "old", ProxyTo: "http://old", RoutingGroup: "adhoc"})
+	gateway.FailWrites("broken")
+	journal := &Journal{}
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRequestObserver(journal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := client.AddOrUpdateBackend(ctx, &Backend{Name: "new", ProxyTo: "http://new", RoutingGroup: "adhoc"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteBackend(ctx, "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOrUpdateBackend(ctx, &Backend{Name: "broken", ProxyTo: "http://broken", RoutingGroup: "adhoc"}); err == nil {
+		t.Fatal("want error")
+	}
+
+	entries := journal.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	lines := strings.Split(journal.Summary(), "\n")
+	want := []string{
+		OperationAddOrUpdateBackend + " new ok",
+		OperationDeleteBackend + " old ok",
+		OperationAddOrUpdateBackend + " broken failed: ",
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i]) {
+			t.Fatalf("summary line %d is %q, want %q", i, line, want[i])
+		}
+	}
+	if entries[2].Err == nil {
+		t.Fatal("failed mutation has no error")
+	}
+}
+
+func TestNilJournal(t *testing.T) {
+	var journal *Journal
+	if entries, summary := journal.Entries(), journal.Summary(); entries != nil || summary != "" {
+		t.Fatalf("got %v and %q, want nothing", entries, summary)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import "sync"
+
+// keyedMutex serializes access per key, different keys dont block each other.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Lock returns function releasing the lock.
+func (km *keyedMutex) Lock(key string) func() {
+	km.mu.Lock()
+	if km.locks == nil {
+		km.locks = map[string]*keyedMutexEntry{}
+	}
+	entry, ok := km.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		km.locks[key] = entry
+	}
+	entry.refs++
+	km.mu.Unlock()
+
+	entry.mu.Lock()
+	return func() {
+		entry.mu.Unlock()
+
+		km.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(km.locks, key)
+		}
+		km.mu.Unlock()
+	}
+}
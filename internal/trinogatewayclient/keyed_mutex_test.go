@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutexDifferentKeys(t *testing.T) {
+	var km keyedMutex
+	unlock := km.Lock("a")
+	defer unlock()
+	locked := make(chan struct{})
+	go func() {
+		km.Lock("b")()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("lock of other key is blocked")
+	}
+}
+
+func TestKeyedMutexReleasesEntries(t *testing.T) {
+	var km keyedMutex
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			km.Lock("a")()
+		}()
+	}
+	wg.Wait()
+	if len(km.locks) != 0 {
+		t.Fatalf("%d lock entries left", len(km.locks))
+	}
+}
+
+// TestMutationsOfSameBackendAreSerialized checks that gateway never sees overlapping writes of one backend.
+func TestMutationsOfSameBackendAreSerialized(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b", ProxyTo: "http://b", RoutingGroup: "g"}); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := client.DeleteBackend(context.Background(), "b"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := maxInFlight.Load(); got != 1 {
+		t.Fatalf("%d concurrent writes of one backend, want 1", got)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redactedValue    = "REDACTED"
+	maxTraceBodySize = 64 * 1024
+)
+
+// traceLoggingEnabled reports whether provider TRACE logs may be written. Plugin sdk gives no access
+// to logger level, so it is derived from the same environment variables. It may be true when
+// TRACE logs are filtered out later, but never false when they are written.
+func traceLoggingEnabled() bool {
+	for _, name := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_TRINOGATEWAY"} {
+		switch strings.ToUpper(os.Getenv(name)) {
+		case "TRACE", "JSON":
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveHeaders returns canonical names of headers which must not be logged.
+// Headers configured by WithHeaders are sensitive too, they often carry custom auth tokens.
+func (tg *trinoGatewayClientHttpImpl) sensitiveHeaders() map[string]struct{} {
+	headers := map[string]struct{}{
+		"Authorization":       {},
+		"Proxy-Authorization": {},
+		"Cookie":              {},
+		"Set-Cookie":          {},
+	}
+	if tg.apiKeyHeader != "" {
+		headers[http.CanonicalHeaderKey(tg.apiKeyHeader)] = struct{}{}
+	}
+	for name := range tg.headers {
+		headers[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+	return headers
+}
+
+func (tg *trinoGatewayClientHttpImpl) redactHeaders(header http.Header) map[string]interface{} {
+	sensitive := tg.sensitiveHeaders()
+	result := make(map[string]interface{}, len(header))
+	for name, values := range header {
+		if _, ok := sensitive[http.CanonicalHeaderKey(name)]; ok {
+			result[name] = redactedValue
+			continue
+		}
+		result[name] = values
+	}
+	return result
+}
+
+// logRequest writes summary at DEBUG and full request and response at TRACE.
+// Dump is built only if TRACE logging is enabled, then head of response body is buffered
+// and replaced, so caller still can read it.
+func (tg *trinoGatewayClientHttpImpl) logRequest(ctx context.Context, request *http.Request, response *http.Response, duration time.Duration) {
+	fields := map[string]interface{}{
+		"method":      request.Method,
+		"path":        request.URL.Path,
+		"duration_ms": duration.Milliseconds(),
+	}
+	if response != nil {
+		fields["status"] = response.StatusCode
+	}
+	tflog.Debug(ctx, "gateway request", fields)
+	if !tg.traceLogging {
+		return
+	}
+
+	traceFields := map[string]interface{}{
+		"method":          request.Method,
+		"url":             request.URL.Redacted(),
+		"request_headers": tg.redactHeaders(request.Header),
+	}
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			requestBody, _ := io.ReadAll(body)
+			body.Close()
+			if request.Header.Get("Content-Encoding") == "gzip" {
+				requestBody = gunzipForTrace(requestBody)
+			}
+			traceFields["request_body"] = string(requestBody)
+		}
+	}
+	if response != nil {
+		traceFields["status"] = response.StatusCode
+		traceFields["response_headers"] = tg.redactHeaders(response.Header)
+		// only head of body is buffered, rest is still streamed to caller
+		responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxTraceBodySize))
+		response.Body = &prefixedBody{
+			Reader: io.MultiReader(bytes.NewReader(responseBody), errReader{err}, response.Body),
+			Closer: response.Body,
+		}
+		traceFields["response_body"] = string(responseBody)
+	}
+	tflog.Trace(ctx, "gateway request dump", traceFields)
+}
+
+// errReader returns err after buffered head is consumed, nil err passes to the next reader.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
+
+type prefixedBody struct {
+	io.Reader
+	io.Closer
+}
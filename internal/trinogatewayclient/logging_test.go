@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLogRequestTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		tfLog    string
+		wantDump bool
+	}{
+		{name: "trace", tfLog: "TRACE", wantDump: true},
+		{name: "json", tfLog: "JSON", wantDump: true},
+		{name: "debug", tfLog: "DEBUG", wantDump: false},
+		{name: "off", tfLog: "", wantDump: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TF_LOG", tt.tfLog)
+			t.Setenv("TF_LOG_PROVIDER", "")
+			t.Setenv("TF_LOG_PROVIDER_TRINOGATEWAY", "")
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Set-Cookie", "session=cookie-secret")
+				_, _ = w.Write([]byte("response-body"))
+			}))
+			defer server.Close()
+
+			client, err := NewTrinoGatewayClient(
+				server.URL,
+				&Auth{Login: "admin", Password: "basic-secret"},
+				WithHeaders(map[string]string{"X-Custom-Token": "header-secret"}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			if err := client.AddOrUpdateBackend(ctx, &Backend{Name: "request-body"}); err != nil {
+				t.Fatal(err)
+			}
+
+			logs := output.String()
+			if !strings.Contains(logs, `"@message":"gateway request"`) {
+				t.Fatalf("no request summary in logs: %s", logs)
+			}
+			if got := strings.Contains(logs, "gateway request dump"); got != tt.wantDump {
+				t.Fatalf("dump logged: %v, want %v: %s", got, tt.wantDump, logs)
+			}
+			if tt.wantDump && (!strings.Contains(logs, "request-body") || !strings.Contains(logs, "response-body")) {
+				t.Fatalf("dump has no bodies: %s", logs)
+			}
+			for _, secret := range []string{"header-secret", "cookie-secret", "YWRtaW46YmFzaWMtc2VjcmV0"} {
+				if strings.Contains(logs, secret) {
+					t.Fatalf("secret %q is logged: %s", secret, logs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is daily time range when gateway changes are allowed.
+// Window with end before start spans midnight, "22:00-06:00" allows changes at night.
+type MaintenanceWindow struct {
+	// Start and End are offsets from midnight in Location
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+	// now is injectable clock, time.Now if nil
+	now func() time.Time
+}
+
+// ParseMaintenanceWindow parses window in "HH:MM-HH:MM" format, times are in location.
+func ParseMaintenanceWindow(window string, location *time.Location) (*MaintenanceWindow, error) {
+	rawStart, rawEnd, ok := strings.Cut(window, "-")
+	if !ok {
+		return nil, fmt.Errorf("cant parse maintenance window %q: expected HH:MM-HH:MM", window)
+	}
+	start, err := parseTimeOfDay(rawStart)
+	if err != nil {
+		return nil, fmt.Errorf("cant parse maintenance window %q: %w", window, err)
+	}
+	end, err := parseTimeOfDay(rawEnd)
+	if err != nil {
+		return nil, fmt.Errorf("cant parse maintenance window %q: %w", window, err)
+	}
+	if start == end {
+		return nil, fmt.Errorf("cant parse maintenance window %q: start equals end", window)
+	}
+	return &MaintenanceWindow{Start: start, End: end, Location: location}, nil
+}
+
+func parseTimeOfDay(raw string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("cant parse time %q: expected HH:MM", raw)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// Contains reports whether t is inside window. Start is inclusive, end is exclusive.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	t = t.In(w.Location)
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+func (w *MaintenanceWindow) String() string {
+	return fmt.Sprintf("%s-%s %s", formatTimeOfDay(w.Start), formatTimeOfDay(w.End), w.Location)
+}
+
+func formatTimeOfDay(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset.Hours()), int(offset.Minutes())%60)
+}
+
+func (w *MaintenanceWindow) clock() time.Time {
+	if w.now != nil {
+		return w.now()
+	}
+	return time.Now()
+}
+
+// OutsideMaintenanceWindowError is returned by mutating methods of client created with WithMaintenanceWindow
+// when called outside of the window.
+type OutsideMaintenanceWindowError struct {
+	Window *MaintenanceWindow
+	Now    time.Time
+}
+
+func (e *OutsideMaintenanceWindowError) Error() string {
+	return fmt.Sprintf("gateway changes are allowed only during maintenance window %s, now is %s", e.Window, e.Now.In(e.Window.Location).Format("15:04"))
+}
+
+// WithMaintenanceWindow makes every mutating method fail with OutsideMaintenanceWindowError outside of window.
+// Now is clock used to check window, time.Now if nil.
+func WithMaintenanceWindow(window *MaintenanceWindow, now func() time.Time) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		window := *window
+		window.now = now
+		tg.maintenanceWindow = &window
+	}
+}
+
+// checkMutationAllowed guards every mutating method.
+func (tg *trinoGatewayClientHttpImpl) checkMutationAllowed() error {
+	if tg.readOnly {
+		return ErrReadOnly
+	}
+	if tg.maintenanceWindow != nil {
+		now := tg.maintenanceWindow.clock()
+		if !tg.maintenanceWindow.Contains(now) {
+			return &OutsideMaintenanceWindowError{Window: tg.maintenanceWindow, Now: now}
+		}
+	}
+	return nil
+}
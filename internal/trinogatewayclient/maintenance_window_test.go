@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	for _, window := range []string{"", "22:00", "22:00-", "25:00-06:00", "22:00-22:00", "10-12"} {
+		if _, err := ParseMaintenanceWindow(window, time.UTC); err == nil {
+			t.Errorf("window %q: want error", window)
+		}
+	}
+	window, err := ParseMaintenanceWindow(" 22:30 - 06:00 ", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window.Start != 22*time.Hour+30*time.Minute || window.End != 6*time.Hour {
+		t.Fatalf("got window %s", window)
+	}
+}
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	tests := []struct {
+		window   string
+		location *time.Location
+		now      time.Time
+		want     bool
+	}{
+		{window: "10:00-12:00", location: time.UTC, now: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), want: true},
+		{window: "10:00-12:00", location: time.UTC, now: time.Date(2026, 1, 1, 11, 59, 59, 0, time.UTC), want: true},
+		{window: "10:00-12:00", location: time.UTC, now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), want: false},
+		{window: "10:00-12:00", location: time.UTC, now: time.Date(2026, 1, 1, 9, 59, 0, 0, time.UTC), want: false},
+		{window: "22:00-06:00", location: time.UTC, now: time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC), want: true},
+		{window: "22:00-06:00", location: time.UTC, now: time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC), want: true},
+		{window: "22:00-06:00", location: time.UTC, now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), want: false},
+		// 09:30 UTC is 10:30 in Berlin in winter
+		{window: "10:00-12:00", location: berlin, now: time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC), want: true},
+		{window: "10:00-12:00", location: berlin, now: time.Date(2026, 1, 1, 11, 30, 0, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		window, err := ParseMaintenanceWindow(tt.window, tt.location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := window.Contains(tt.now); got != tt.want {
+			t.Errorf("window %s contains %s: %v, want %v", window, tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestMaintenanceWindowGuardsMutations(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	window, err := ParseMaintenanceWindow("22:00-06:00", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithMaintenanceWindow(window, func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mutations := map[string]func() error{
+		"add":        func() error { return client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b"}) },
+		"delete":     func() error { return client.DeleteBackend(context.Background(), "b") },
+		"deactivate": func() error { return client.DeactivateBackend(context.Background(), "b") },
+		"default":    func() error { return client.SetDefaultRoutingGroup(context.Background(), "g") },
+		"replace": func() error {
+			_, err := client.ReplaceAllBackends(context.Background(), nil)
+			return err
+		},
+	}
+	for name, mutate := range mutations {
+		var windowErr *OutsideMaintenanceWindowError
+		if err := mutate(); !errors.As(err, &windowErr) {
+			t.Errorf("%s: got %v, want OutsideMaintenanceWindowError", name, err)
+		}
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatalf("reads should work outside of window: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("gateway got %d requests, want only read", got)
+	}
+
+	now = time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC)
+	if err := client.DeleteBackend(context.Background(), "b"); err != nil {
+		t.Fatalf("mutation inside window: %v", err)
+	}
+}
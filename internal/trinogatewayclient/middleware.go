@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import "net/http"
+
+// Middleware wraps transport of gateway requests, e.g. to sign requests or add tracing.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithRoundTripper installs middlewares around client transport.
+// The first middleware is the outermost one and sees request first.
+// Middlewares run after auth and default headers are set and after retries and logging decided
+// what to send: every retry attempt passes through them, and TRACE logs show requests before middleware changes.
+func WithRoundTripper(middlewares ...Middleware) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.middlewares = append(tg.middlewares, middlewares...)
+	}
+}
+
+func wrapTransport(transport http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return transport
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// headerMiddleware appends name to X-Middlewares header of every request.
+func headerMiddleware(name string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			request = request.Clone(request.Context())
+			request.Header.Add("X-Middlewares", name)
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+func TestRoundTripperOrder(t *testing.T) {
+	var middlewares, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middlewares = strings.Join(r.Header.Values("X-Middlewares"), ",")
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(
+		server.URL,
+		&Auth{Login: "admin", Password: "secret"},
+		WithRoundTripper(headerMiddleware("outer"), headerMiddleware("inner")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if middlewares != "outer,inner" {
+		t.Fatalf("got middlewares %q, want outer,inner", middlewares)
+	}
+	if authorization == "" {
+		t.Fatal("auth is not set before middlewares")
+	}
+}
+
+func TestRoundTripperSeesRetries(t *testing.T) {
+	var attempts, calls atomic.Int32
+	server := unavailableServer(t, &attempts)
+	counting := func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			return next.RoundTrip(request)
+		})
+	}
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRetry(1, 0), WithRoundTripper(counting))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err == nil {
+		t.Fatal("want error")
+	}
+	if got, want := calls.Load(), attempts.Load(); got != 2 || got != want {
+		t.Fatalf("middleware saw %d requests, gateway got %d, want 2", got, want)
+	}
+}
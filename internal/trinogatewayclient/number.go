@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Number is integer field of gateway entity. Gateways may format it as 1.0, 1e0 or "1",
+// it is decoded to canonical integer, so state does not flap between representations.
+type Number int64
+
+func (n *Number) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	raw := string(bytes.Trim(data, `"`))
+	parsed, err := parseNumber(raw)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+func (n *Number) UnmarshalYAML(value *yaml.Node) error {
+	if value.Tag == "!!null" {
+		return nil
+	}
+	parsed, err := parseNumber(value.Value)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
+func parseNumber(raw string) (Number, error) {
+	if parsed, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return Number(parsed), nil
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil || parsed != math.Trunc(parsed) || math.Abs(parsed) > math.MaxInt64 {
+		return 0, fmt.Errorf("cant parse %q as integer", raw)
+	}
+	return Number(parsed), nil
+}
+
+var (
+	_ json.Unmarshaler = (*Number)(nil)
+	_ yaml.Unmarshaler = (*Number)(nil)
+)
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestBackendWeightFormats(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        *Number
+		wantErr     bool
+	}{
+		{name: "integer", body: `[{"name":"b","weight":3}]`, want: numberPtr(3)},
+		{name: "float", body: `[{"name":"b","weight":3.0}]`, want: numberPtr(3)},
+		{name: "exponent", body: `[{"name":"b","weight":3e0}]`, want: numberPtr(3)},
+		{name: "string", body: `[{"name":"b","weight":"3"}]`, want: numberPtr(3)},
+		{name: "null", body: `[{"name":"b","weight":null}]`},
+		{name: "missing", body: `[{"name":"b"}]`},
+		{name: "fraction", body: `[{"name":"b","weight":1.5}]`, wantErr: true},
+		{name: "not number", body: `[{"name":"b","weight":"heavy"}]`, wantErr: true},
+		{name: "yaml float", contentType: ContentTypeYAML, body: "- name: b\n  weight: 3.0\n", want: numberPtr(3)},
+		{name: "yaml string", contentType: ContentTypeYAML, body: "- name: b\n  weight: \"3\"\n", want: numberPtr(3)},
+		{name: "yaml null", contentType: ContentTypeYAML, body: "- name: b\n  weight: null\n"},
+		{name: "yaml fraction", contentType: ContentTypeYAML, body: "- name: b\n  weight: 1.5\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mediaType := "application/json"
+			var opts []Option
+			if tt.contentType == ContentTypeYAML {
+				mediaType = yamlMediaType
+				opts = append(opts, WithContentType(tt.contentType))
+			}
+			server := staticServer(t, http.StatusOK, mediaType, tt.body)
+			client, err := NewTrinoGatewayClient(server.URL, nil, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backends, err := client.GetAllBackends(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := backends[0].Weight
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("got weight %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func numberPtr(n Number) *Number {
+	return &n
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RequestObserver is notified after every request to gateway.
+// status is zero if request failed without response.
+type RequestObserver interface {
+	ObserveRequest(method string, path string, status int, duration time.Duration)
+}
+
+// CacheObserver may be additionally implemented by RequestObserver
+// to receive backends cache hits and misses.
+type CacheObserver interface {
+	ObserveCache(hit bool)
+}
+
+type noopRequestObserver struct{}
+
+func (noopRequestObserver) ObserveRequest(string, string, int, time.Duration) {}
+
+// WithRequestObserver adds observer for latency and error rate metrics.
+// Observers added by several options are all notified in order they were added.
+func WithRequestObserver(observer RequestObserver) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		if observer == nil {
+			return
+		}
+		switch current := tg.observer.(type) {
+		case noopRequestObserver:
+			tg.observer = observer
+		case requestObservers:
+			tg.observer = append(slices.Clip(current), observer)
+		default:
+			tg.observer = requestObservers{current, observer}
+		}
+	}
+}
+
+// requestObservers fans out to every observer, optional interfaces are passed to observers implementing them.
+type requestObservers []RequestObserver
+
+func (o requestObservers) ObserveRequest(method string, path string, status int, duration time.Duration) {
+	for _, observer := range o {
+		observer.ObserveRequest(method, path, status, duration)
+	}
+}
+
+func (o requestObservers) ObserveCache(hit bool) {
+	for _, observer := range o {
+		if cacheObserver, ok := observer.(CacheObserver); ok {
+			cacheObserver.ObserveCache(hit)
+		}
+	}
+}
+
+func (o requestObservers) ObserveMutation(operation string, target string, err error) {
+	for _, observer := range o {
+		if mutationObserver, ok := observer.(MutationObserver); ok {
+			mutationObserver.ObserveMutation(operation, target, err)
+		}
+	}
+}
+
+func (tg *trinoGatewayClientHttpImpl) observeCache(hit bool) {
+	if cacheObserver, ok := tg.observer.(CacheObserver); ok {
+		cacheObserver.ObserveCache(hit)
+	}
+}
+
+// sendOnce sends request and reports it to observer.
+func (tg *trinoGatewayClientHttpImpl) sendOnce(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	request, release := tg.withCloseContext(request)
+	response, err := tg.httpclient.Do(request)
+	if err != nil {
+		release()
+	} else {
+		response.Body = &releasingBody{ReadCloser: response.Body, release: release}
+	}
+	status := 0
+	if response != nil {
+		status = response.StatusCode
+	}
+	duration := time.Since(start)
+	tg.observer.ObserveRequest(request.Method, request.URL.Path, status, duration)
+	tg.recordSlowRequest(request.Context(), request.Method, request.URL.Path, duration)
+	tg.logRequest(request.Context(), request, response, duration)
+	if err != nil {
+		if tg.closed.Err() != nil {
+			return nil, fmt.Errorf("%w: %w", errClientClosed, err)
+		}
+		return nil, contextError(err)
+	}
+	return response, nil
+}
+
+// contextError makes aborted and timed out requests distinguishable in diagnostics.
+func contextError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("request canceled (terraform operation was interrupted): %w", err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("request timed out: %w", err)
+	}
+	return err
+}
@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type observedRequest struct {
+	method string
+	path   string
+	status int
+}
+
+// recordingObserver records requests, it is not safe for concurrent use.
+type recordingObserver struct {
+	requests []observedRequest
+}
+
+func (o *recordingObserver) ObserveRequest(method string, path string, status int, duration time.Duration) {
+	o.requests = append(o.requests, observedRequest{method: method, path: path, status: status})
+}
+
+func TestObserveRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	observer := &recordingObserver{}
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRequestObserver(observer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b"}); err == nil {
+		t.Fatal("want error")
+	}
+	server.Close()
+	if _, err := client.GetAllBackends(context.Background()); err == nil {
+		t.Fatal("want error")
+	}
+
+	want := []observedRequest{
+		{method: http.MethodGet, path: DefaultListPath, status: http.StatusOK},
+		{method: http.MethodPost, path: "/entity", status: http.StatusInternalServerError},
+		{method: http.MethodGet, path: DefaultListPath, status: 0},
+	}
+	if !slices.Equal(observer.requests, want) {
+		t.Fatalf("observed %v, want %v", observer.requests, want)
+	}
+}
+
+type countingObserver struct {
+	requests, hits, misses atomic.Int32
+}
+
+func (o *countingObserver) ObserveRequest(string, string, int, time.Duration) {
+	o.requests.Add(1)
+}
+
+func (o *countingObserver) ObserveCache(hit bool) {
+	if hit {
+		o.hits.Add(1)
+	} else {
+		o.misses.Add(1)
+	}
+}
+
+func TestObserveCacheConcurrently(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`[{"name":"b","proxyTo":"http://b","routingGroup":"g","active":true}]`))
+	}))
+	defer server.Close()
+	observer := &countingObserver{}
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRequestObserver(observer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	const reads = 20
+	var wg sync.WaitGroup
+	for i := 0; i < reads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetAllBackends(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if hits, misses := observer.hits.Load(), observer.misses.Load(); hits != reads || misses != 1 {
+		t.Fatalf("got %d hits and %d misses, want %d and 1", hits, misses, reads)
+	}
+	if got := observer.requests.Load(); got != reads+1 {
+		t.Fatalf("observed %d requests, want %d", got, reads+1)
+	}
+}
+
+func TestRequestObserversFanOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	first, second := &countingObserver{}, &recordingObserver{}
+	journal := &Journal{}
+	client, err := NewTrinoGatewayClient(server.URL, nil,
+		WithRequestObserver(first),
+		WithRequestObserver(nil),
+		WithRequestObserver(second),
+		WithRequestObserver(journal),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := first.requests.Load(); got != 2 {
+		t.Fatalf("first observer got %d requests, want 2", got)
+	}
+	if got := first.misses.Load(); got != 1 {
+		t.Fatalf("first observer got %d cache misses, want 1", got)
+	}
+	if got := len(second.requests); got != 2 {
+		t.Fatalf("second observer got %d requests, want 2", got)
+	}
+	if got := len(journal.Entries()); got != 1 {
+		t.Fatalf("journal got %d mutations, want 1", got)
+	}
+}
+
+// requestOnlyObserver does not implement CacheObserver.
+type requestOnlyObserver struct{}
+
+func (requestOnlyObserver) ObserveRequest(string, string, int, time.Duration) {}
+
+func TestObserverWithoutCacheObserver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRequestObserver(requestOnlyObserver{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContextError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "canceled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(50*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			want:    "request canceled",
+			wantErr: context.Canceled,
+		},
+		{
+			name: "timeout",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 50*time.Millisecond)
+			},
+			want:    "request timed out",
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			_, err := client.GetAllBackends(ctx)
+			if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
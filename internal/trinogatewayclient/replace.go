@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ReplaceSummary lists names of backends successfully changed by ReplaceAllBackends and ReconcileBackends.
+type ReplaceSummary struct {
+	Added     []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+}
+
+// ReplaceAllBackends makes gateway backends equal to desired ones.
+// It keeps going after failed operations, so summary reflects everything applied,
+// and returned error joins all failures.
+func (tg *trinoGatewayClientHttpImpl) ReplaceAllBackends(ctx context.Context, desired []*Backend) (*ReplaceSummary, error) {
+	return tg.reconcileBackends(ctx, desired, func(string) bool { return true })
+}
+
+// ReconcileBackends is ReplaceAllBackends limited to owned backends,
+// backends missing in desired are deleted only if their names are in owned.
+func (tg *trinoGatewayClientHttpImpl) ReconcileBackends(ctx context.Context, desired []*Backend, owned []string) (*ReplaceSummary, error) {
+	ownedNames := make(map[string]struct{}, len(owned))
+	for _, name := range owned {
+		ownedNames[name] = struct{}{}
+	}
+	return tg.reconcileBackends(ctx, desired, func(name string) bool {
+		_, ok := ownedNames[name]
+		return ok
+	})
+}
+
+func (tg *trinoGatewayClientHttpImpl) reconcileBackends(ctx context.Context, desired []*Backend, owns func(name string) bool) (*ReplaceSummary, error) {
+	if err := tg.checkMutationAllowed(); err != nil {
+		return nil, err
+	}
+	current, err := tg.GetAllBackends(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentByName := make(map[string]*Backend, len(current))
+	for _, backend := range current {
+		currentByName[backend.Name] = backend
+	}
+	desiredNames := make(map[string]struct{}, len(desired))
+
+	summary := &ReplaceSummary{}
+	var errs []error
+	for _, backend := range desired {
+		if _, ok := desiredNames[backend.Name]; ok {
+			errs = append(errs, fmt.Errorf("backend %s: duplicated in desired backends", backend.Name))
+			continue
+		}
+		desiredNames[backend.Name] = struct{}{}
+
+		existing, exists := currentByName[backend.Name]
+		if exists && existing.Equal(backend) {
+			summary.Unchanged = append(summary.Unchanged, backend.Name)
+			continue
+		}
+		if err := tg.AddOrUpdateBackend(ctx, backend); err != nil {
+			errs = append(errs, fmt.Errorf("backend %s: %w", backend.Name, err))
+			continue
+		}
+		if exists {
+			summary.Updated = append(summary.Updated, backend.Name)
+		} else {
+			summary.Added = append(summary.Added, backend.Name)
+		}
+	}
+
+	for _, backend := range current {
+		if _, ok := desiredNames[backend.Name]; ok || !owns(backend.Name) {
+			continue
+		}
+		if err := tg.DeleteBackend(ctx, backend.Name); err != nil {
+			errs = append(errs, fmt.Errorf("backend %s: %w", backend.Name, err))
+			continue
+		}
+		summary.Deleted = append(summary.Deleted, backend.Name)
+	}
+
+	return summary, errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestReplaceAllBackends(t *testing.T) {
+	adhoc := Backend{Name: "adhoc", ProxyTo: "http://adhoc", RoutingGroup: "adhoc", Active: true}
+	etl := Backend{Name: "etl", ProxyTo: "http://etl", RoutingGroup: "etl", Active: true}
+	movedEtl := etl
+	movedEtl.RoutingGroup = "adhoc"
+	tests := []struct {
+		name        string
+		current     []Backend
+		desired     []Backend
+		failWrites  []string
+		wantSummary ReplaceSummary
+		wantNames   []string
+		wantErr     string
+	}{
+		{
+			name:        "add",
+			desired:     []Backend{adhoc, etl},
+			wantSummary: ReplaceSummary{Added: []string{"adhoc", "etl"}},
+			wantNames:   []string{"adhoc", "etl"},
+		},
+		{
+			name:        "delete",
+			current:     []Backend{adhoc, etl},
+			desired:     []Backend{adhoc},
+			wantSummary: ReplaceSummary{Deleted: []string{"etl"}, Unchanged: []string{"adhoc"}},
+			wantNames:   []string{"adhoc"},
+		},
+		{
+			name:        "mixed",
+			current:     []Backend{adhoc, etl},
+			desired:     []Backend{movedEtl, {Name: "new", ProxyTo: "http://new", RoutingGroup: "adhoc"}},
+			wantSummary: ReplaceSummary{Added: []string{"new"}, Updated: []string{"etl"}, Deleted: []string{"adhoc"}},
+			wantNames:   []string{"etl", "new"},
+		},
+		{
+			name:        "failures do not stop others",
+			current:     []Backend{adhoc, etl},
+			desired:     []Backend{movedEtl, {Name: "new", ProxyTo: "http://new", RoutingGroup: "adhoc"}},
+			failWrites:  []string{"etl", "adhoc"},
+			wantSummary: ReplaceSummary{Added: []string{"new"}},
+			wantNames:   []string{"adhoc", "etl", "new"},
+			wantErr:     "backend etl",
+		},
+		{
+			name:        "duplicated desired backend",
+			desired:     []Backend{adhoc, adhoc},
+			wantSummary: ReplaceSummary{Added: []string{"adhoc"}},
+			wantNames:   []string{"adhoc"},
+			wantErr:     "duplicated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gateway, server := newFakeGateway(t, tt.current...)
+			for _, name := range tt.failWrites {
+				gateway.FailWrites(name)
+			}
+			client, err := NewTrinoGatewayClient(server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			desired := make([]*Backend, 0, len(tt.desired))
+			for i := range tt.desired {
+				desired = append(desired, &tt.desired[i])
+			}
+			summary, err := client.ReplaceAllBackends(context.Background(), desired)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+			for _, names := range [][]string{summary.Added, summary.Updated, summary.Deleted, summary.Unchanged} {
+				sort.Strings(names)
+			}
+			if !reflect.DeepEqual(*summary, tt.wantSummary) {
+				t.Fatalf("got summary %+v, want %+v", *summary, tt.wantSummary)
+			}
+			if names := gateway.Names(); !reflect.DeepEqual(names, tt.wantNames) {
+				t.Fatalf("gateway has backends %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestReconcileBackends(t *testing.T) {
+	adhoc := Backend{Name: "adhoc", ProxyTo: "http://adhoc", RoutingGroup: "adhoc", Active: true}
+	etl := Backend{Name: "etl", ProxyTo: "http://etl", RoutingGroup: "etl", Active: true}
+	standalone := Backend{Name: "standalone", ProxyTo: "http://standalone", RoutingGroup: "adhoc", Active: true}
+	gateway, server := newFakeGateway(t, adhoc, etl, standalone)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := client.ReconcileBackends(context.Background(), []*Backend{&adhoc}, []string{"adhoc", "etl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ReplaceSummary{Deleted: []string{"etl"}, Unchanged: []string{"adhoc"}}
+	if !reflect.DeepEqual(*summary, want) {
+		t.Fatalf("got summary %+v, want %+v", *summary, want)
+	}
+	if names := gateway.Names(); !reflect.DeepEqual(names, []string{"adhoc", "standalone"}) {
+		t.Fatalf("gateway has backends %v, want not owned standalone kept", names)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultIdempotencyKeyHeader = "Idempotency-Key"
+
+	retryInitialBackoff = 500 * time.Millisecond
+	retryMaxBackoff     = 10 * time.Second
+)
+
+type retryConfig struct {
+	maxRetries int
+	// idempotencyKeyHeader carries key shared by all attempts of single upsert
+	idempotencyKeyHeader string
+	// maxElapsedTime bounds time spent on all attempts of single request, zero means no bound
+	maxElapsedTime time.Duration
+}
+
+// WithRetry retries requests failed by network errors or 429/502/503/504 responses
+// with exponential backoff. Retrying stops after maxRetries retries
+// or when next attempt would start after maxElapsedTime since the first one.
+func WithRetry(maxRetries int, maxElapsedTime time.Duration) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.retry.maxRetries = maxRetries
+		tg.retry.maxElapsedTime = maxElapsedTime
+	}
+}
+
+// WithIdempotencyKeyHeader sets header of idempotency key sent with upserts when retries are enabled.
+func WithIdempotencyKeyHeader(header string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.retry.idempotencyKeyHeader = header
+	}
+}
+
+// setIdempotencyKey lets gateway supporting idempotency recognize retried upsert.
+// Retries clone request headers, so all attempts share the key.
+func (tg *trinoGatewayClientHttpImpl) setIdempotencyKey(request *http.Request) error {
+	if tg.retry.maxRetries == 0 {
+		return nil
+	}
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("cant generate idempotency key: %w", err)
+	}
+	request.Header.Set(tg.retry.idempotencyKeyHeader, hex.EncodeToString(key))
+	return nil
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// send sends request retrying transient failures, last response or error is returned.
+func (tg *trinoGatewayClientHttpImpl) send(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	start := time.Now()
+	backoff := retryInitialBackoff
+	for attempt := 0; ; attempt++ {
+		if err := tg.breaker.allow(); err != nil {
+			return nil, err
+		}
+		response, err := tg.sendOnce(request)
+		retryable := (err != nil && ctx.Err() == nil) || (err == nil && retryableStatus(response.StatusCode))
+		tg.breaker.record(retryable)
+		if !retryable || attempt >= tg.retry.maxRetries {
+			return response, err
+		}
+		if tg.retry.maxElapsedTime > 0 && time.Since(start)+backoff > tg.retry.maxElapsedTime {
+			return response, err
+		}
+		if request.Body != nil && request.GetBody == nil {
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if err := tg.sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff = min(backoff*2, retryMaxBackoff)
+
+		request, err = rewindRequest(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewindRequest clones request with fresh body, so it can be sent again.
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	rewound := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("cant rewind request body: %w", err)
+		}
+		rewound.Body = body
+	}
+	return rewound, nil
+}
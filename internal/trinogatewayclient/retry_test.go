@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// unavailableServer answers 503 to every request and counts them.
+func unavailableServer(t *testing.T, attempts *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	var attempts atomic.Int32
+	server := unavailableServer(t, &attempts)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRetry(2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err == nil {
+		t.Fatal("want error")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Fatalf("got %d attempts, want 3", got)
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	var attempts atomic.Int32
+	server := unavailableServer(t, &attempts)
+	// backoffs are 500ms and 1s, so third attempt would start after 1.5s
+	budget := 1200 * time.Millisecond
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRetry(100, budget))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = client.GetAllBackends(context.Background())
+	elapsed := time.Since(start)
+	if err == nil {
+		t.Fatal("want error")
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("got %d attempts, want 2", got)
+	}
+	if elapsed > budget {
+		t.Fatalf("retrying took %s, budget is %s", elapsed, budget)
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantHeader string
+	}{
+		{name: "default header", opts: []Option{WithRetry(1, 0)}, wantHeader: DefaultIdempotencyKeyHeader},
+		{name: "custom header", opts: []Option{WithRetry(1, 0), WithIdempotencyKeyHeader("X-Request-Key")}, wantHeader: "X-Request-Key"},
+		{name: "no retries", wantHeader: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				header := tt.wantHeader
+				if header == "" {
+					header = DefaultIdempotencyKeyHeader
+				}
+				keys = append(keys, r.Header.Get(header))
+				// first attempt of each upsert fails
+				if len(keys)%2 == 1 && tt.wantHeader != "" {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 2; i++ {
+				if err := client.AddOrUpdateBackend(context.Background(), &Backend{Name: "trino-1"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.wantHeader == "" {
+				for _, key := range keys {
+					if key != "" {
+						t.Fatalf("got idempotency key %q without retries", key)
+					}
+				}
+				return
+			}
+			if len(keys) != 4 {
+				t.Fatalf("got %d attempts, want 4", len(keys))
+			}
+			if keys[0] == "" || keys[0] != keys[1] || keys[2] != keys[3] {
+				t.Fatalf("attempts of one upsert have different keys: %q", keys)
+			}
+			if keys[0] == keys[2] {
+				t.Fatalf("different upserts share key %q", keys[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// WithRunIdHeader sends run id in header, so gateway logs can be correlated with terraform runs.
+// Run id is generated once per client, which is once per terraform run for provider.
+// Terraform operation ids are not exposed to providers, so requests are not told apart by operation.
+func WithRunIdHeader(header string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.runIdHeader = header
+	}
+}
+
+// WithRunId sets run id instead of generated one, so several clients of one run share it.
+func WithRunId(id string) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.runId = id
+	}
+}
+
+// RunId returns id sent with requests.
+func (tg *trinoGatewayClientHttpImpl) RunId() string {
+	return tg.runId
+}
+
+func newRunId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func (tg *trinoGatewayClientHttpImpl) setRunId(request *http.Request) {
+	if tg.runIdHeader == "" {
+		return
+	}
+	request.Header.Set(tg.runIdHeader, tg.runId)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// runIdServer records X-Run-Id header of every request.
+func runIdServer(t *testing.T, ids *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*ids = append(*ids, r.Header.Get("X-Run-Id"))
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunIdHeader(t *testing.T) {
+	var ids []string
+	server := runIdServer(t, &ids)
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithRunIdHeader("X-Run-Id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewTrinoGatewayClient(server.URL, nil, WithRunIdHeader("X-Run-Id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAllBackends(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := other.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if ids[0] == "" || ids[0] != client.RunId() || ids[1] != ids[0] {
+		t.Fatalf("requests got %q, want run id %q", ids[:2], client.RunId())
+	}
+	if ids[2] == ids[0] {
+		t.Fatalf("clients share run id %q", ids[0])
+	}
+}
+
+func TestRunIdShared(t *testing.T) {
+	var ids []string
+	server := runIdServer(t, &ids)
+	for i := 0; i < 2; i++ {
+		client, err := NewTrinoGatewayClient(server.URL, nil, WithRunIdHeader("X-Run-Id"), WithRunId("run-1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAllBackends(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ids[0] != "run-1" || ids[1] != "run-1" {
+		t.Fatalf("got ids %q, want shared run id", ids)
+	}
+}
+
+func TestRunIdHeaderDisabled(t *testing.T) {
+	var ids []string
+	server := runIdServer(t, &ids)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if ids[0] != "" {
+		t.Fatalf("got id %q without run id header option", ids[0])
+	}
+}
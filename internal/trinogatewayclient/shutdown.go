@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+var errClientClosed = errors.New("client is closed")
+
+// sleep waits for d. It returns early with error when ctx is done, e.g. on Ctrl-C, or client is closed,
+// so backoffs never delay interruption.
+func (tg *trinoGatewayClientHttpImpl) sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return contextError(ctx.Err())
+	case <-tg.closed.Done():
+		return errClientClosed
+	case <-timer.C:
+		return nil
+	}
+}
+
+// withCloseContext makes request canceled by Close as well as by its own context.
+// Returned release func must be called once request and its response body are done.
+func (tg *trinoGatewayClientHttpImpl) withCloseContext(request *http.Request) (*http.Request, func()) {
+	ctx, cancel := context.WithCancel(request.Context())
+	stop := context.AfterFunc(tg.closed, cancel)
+	return request.WithContext(ctx), func() {
+		stop()
+		cancel()
+	}
+}
+
+// releasingBody calls release on Close, so request context lives until body is read.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoffInterrupted(t *testing.T) {
+	tests := []struct {
+		name      string
+		interrupt func(cancel context.CancelFunc, client TrinoGatewayClient)
+		wantErr   error
+	}{
+		{
+			name:      "context canceled",
+			interrupt: func(cancel context.CancelFunc, client TrinoGatewayClient) { cancel() },
+			wantErr:   context.Canceled,
+		},
+		{
+			name:      "client closed",
+			interrupt: func(cancel context.CancelFunc, client TrinoGatewayClient) { _ = client.Close() },
+			wantErr:   errClientClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := unavailableServer(t, &attempts)
+			// first backoff is 500ms, interruption comes in the middle of it
+			client, err := NewTrinoGatewayClient(server.URL, nil, WithRetry(5, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			time.AfterFunc(100*time.Millisecond, func() { tt.interrupt(cancel, client) })
+
+			start := time.Now()
+			_, err = client.GetAllBackends(ctx)
+			elapsed := time.Since(start)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if elapsed >= retryInitialBackoff {
+				t.Fatalf("returned after %s, backoff was not interrupted", elapsed)
+			}
+			if got := attempts.Load(); got != 1 {
+				t.Fatalf("got %d attempts, want 1", got)
+			}
+		})
+	}
+}
+
+func TestClosedClientFailsRequests(t *testing.T) {
+	var attempts atomic.Int32
+	server := unavailableServer(t, &attempts)
+	client, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetAllBackends(context.Background()); err == nil {
+		t.Fatal("want error")
+	}
+	if got := attempts.Load(); got != 0 {
+		t.Fatalf("gateway got %d requests after close", got)
+	}
+}
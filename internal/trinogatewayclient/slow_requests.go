@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithSlowRequestThreshold records requests slower than threshold into SlowRequests of request context.
+func WithSlowRequestThreshold(threshold time.Duration) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.slowRequestThreshold = threshold
+	}
+}
+
+// SlowRequest is request which took longer than threshold set by WithSlowRequestThreshold.
+type SlowRequest struct {
+	Method   string
+	Path     string
+	Duration time.Duration
+}
+
+// SlowRequests collects slow requests made with context returned by ContextWithSlowRequests.
+type SlowRequests struct {
+	mu       sync.Mutex
+	requests []SlowRequest
+}
+
+type slowRequestsContextKey struct{}
+
+// ContextWithSlowRequests returns context collecting slow requests made with it.
+func ContextWithSlowRequests(ctx context.Context) (context.Context, *SlowRequests) {
+	slowRequests := &SlowRequests{}
+	return context.WithValue(ctx, slowRequestsContextKey{}, slowRequests), slowRequests
+}
+
+// Requests returns collected slow requests in order they were finished.
+func (s *SlowRequests) Requests() []SlowRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SlowRequest(nil), s.requests...)
+}
+
+func (tg *trinoGatewayClientHttpImpl) recordSlowRequest(ctx context.Context, method string, path string, duration time.Duration) {
+	if tg.slowRequestThreshold == 0 || duration <= tg.slowRequestThreshold {
+		return
+	}
+	slowRequests, ok := ctx.Value(slowRequestsContextKey{}).(*SlowRequests)
+	if !ok {
+		return
+	}
+	slowRequests.mu.Lock()
+	defer slowRequests.mu.Unlock()
+	slowRequests.requests = append(slowRequests.requests, SlowRequest{Method: method, Path: path, Duration: duration})
+}
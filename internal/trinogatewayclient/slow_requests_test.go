@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSlowRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gateway/routingGroup/default" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithSlowRequestThreshold(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, slowRequests := ContextWithSlowRequests(context.Background())
+	if _, err := client.GetAllBackends(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SetDefaultRoutingGroup(ctx, "adhoc"); err != nil {
+		t.Fatal(err)
+	}
+	// requests made without tracking context are not recorded anywhere
+	if err := client.SetDefaultRoutingGroup(context.Background(), "adhoc"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := slowRequests.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d slow requests, want 1: %v", len(requests), requests)
+	}
+	if requests[0].Method != http.MethodPost || requests[0].Path != "/gateway/routingGroup/default" {
+		t.Fatalf("got slow request %s %s", requests[0].Method, requests[0].Path)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	ndjsonMediaType = "application/x-ndjson"
+	// streamProgressInterval is number of backends between progress log records
+	streamProgressInterval = 1000
+)
+
+// WithStreamingList asks gateway for backends list as json lines, one backend per line,
+// so large lists are decoded incrementally. Gateway answering with json array is handled as usual,
+// upstream gateway has no json lines variant. Ignored with ContentTypeYAML.
+func WithStreamingList() Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.streamingList = true
+	}
+}
+
+// setStreamingHeaders prefers json lines, json array is accepted as fallback.
+func (tg *trinoGatewayClientHttpImpl) setStreamingHeaders(request *http.Request) {
+	if !tg.streamingList || tg.contentType == ContentTypeYAML {
+		return
+	}
+	request.Header.Set("Accept", ndjsonMediaType+", application/json;q=0.9")
+}
+
+func isNdjsonResponse(response *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	return err == nil && mediaType == ndjsonMediaType
+}
+
+// decodeBackendsStream decodes json lines backends list, unknown fields are error with WithStrictJSON.
+func (tg *trinoGatewayClientHttpImpl) decodeBackendsStream(ctx context.Context, reader io.Reader) ([]*Backend, error) {
+	backends := []*Backend{}
+	decoder := json.NewDecoder(reader)
+	if tg.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+	for {
+		backend := &Backend{}
+		if err := decoder.Decode(backend); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("cant decode backend #%d: %w", len(backends)+1, err)
+		}
+		backends = append(backends, backend)
+		if len(backends)%streamProgressInterval == 0 {
+			tflog.Debug(ctx, "streaming backends list", map[string]interface{}{"decoded": len(backends)})
+		}
+	}
+	return backends, nil
+}
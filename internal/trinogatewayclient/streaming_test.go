@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStreamingList(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		opts        []Option
+		wantNames   []string
+		wantErr     string
+	}{
+		{
+			name:        "json lines",
+			contentType: ndjsonMediaType + "; charset=utf-8",
+			body:        "{\"name\":\"trino-1\"}\n{\"name\":\"trino-2\"}\n",
+			wantNames:   []string{"trino-1", "trino-2"},
+		},
+		{
+			name:        "json array fallback",
+			contentType: "application/json",
+			body:        `[{"name":"trino-1"},{"name":"trino-2"}]`,
+			wantNames:   []string{"trino-1", "trino-2"},
+		},
+		{
+			name:        "empty stream",
+			contentType: ndjsonMediaType,
+			wantNames:   []string{},
+		},
+		{
+			name:        "broken line",
+			contentType: ndjsonMediaType,
+			body:        "{\"name\":\"trino-1\"}\n{\"name\":\n",
+			wantErr:     "cant decode backend #2",
+		},
+		{
+			name:        "strict unknown field",
+			contentType: ndjsonMediaType,
+			body:        "{\"name\":\"trino-1\",\"labels\":{}}\n",
+			opts:        []Option{WithStrictJSON()},
+			wantErr:     "cant decode backend #1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var accept string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accept = r.Header.Get("Accept")
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client, err := NewTrinoGatewayClient(server.URL, nil, append(tt.opts, WithStreamingList())...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			backends, err := client.GetAllBackends(context.Background())
+			if !strings.HasPrefix(accept, ndjsonMediaType) {
+				t.Fatalf("got Accept %q, want json lines preferred", accept)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got %v, want error %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, backend := range backends {
+				names = append(names, backend.Name)
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Fatalf("got backends %q, want %q", names, tt.wantNames)
+			}
+		})
+	}
+}
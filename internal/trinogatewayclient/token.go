@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// TokenSource returns fresh bearer token.
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithBearerTokenSource authenticates requests with bearer token obtained from source.
+// Token is cached and obtained again once gateway responds 401.
+func WithBearerTokenSource(source TokenSource) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.tokenSource = source
+	}
+}
+
+// CommandTokenSource runs shell command and uses its trimmed stdout as token.
+func CommandTokenSource(command string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "sh", "-c", command)
+		}
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf(
+				"token command failed: %w, stderr: %s",
+				err,
+				stderr.Bytes()[:min(stderr.Len(), maxResponseBodyLogSize)],
+			)
+		}
+		token := strings.TrimSpace(stdout.String())
+		if token == "" {
+			return "", fmt.Errorf("token command returned empty token")
+		}
+		return token, nil
+	}
+}
+
+// cachedToken guards token obtained from TokenSource.
+type cachedToken struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (c *cachedToken) get(ctx context.Context, source TokenSource, refresh bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && !refresh {
+		return c.token, nil
+	}
+	token, err := source(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token = token
+	return token, nil
+}
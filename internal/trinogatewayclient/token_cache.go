@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenCacheExpiryMargin keeps tokens about to expire out of use.
+const tokenCacheExpiryMargin = 30 * time.Second
+
+type tokenCacheEntry struct {
+	Token string `json:"token"`
+	// ExpiresAt is zero if token expiry is unknown
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DiskCachedTokenSource shares tokens of source between processes through files in dir,
+// so short-lived terraform runs dont fetch new token every time.
+// Files are not encrypted, tokens are protected only by 0600 permissions of files and 0700 of created dir.
+// Cache file is named by hash of key, which should identify credentials, e.g. endpoint and token command.
+// Failure to write cache is logged, token is still returned.
+// Expiry is taken from exp claim of jwt tokens, other tokens are reused until gateway rejects them.
+// Only the first token of process may come from cache, later calls are refreshes after 401 and go to source.
+func DiskCachedTokenSource(dir string, key string, source TokenSource) TokenSource {
+	cache := &diskTokenCache{dir: dir, key: key}
+	var mu sync.Mutex
+	cacheUsed := false
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !cacheUsed {
+			cacheUsed = true
+			if entry, ok := cache.load(); ok && (entry.ExpiresAt.IsZero() || time.Until(entry.ExpiresAt) > tokenCacheExpiryMargin) {
+				return entry.Token, nil
+			}
+		}
+		token, err := source(ctx)
+		if err != nil {
+			return "", err
+		}
+		if err := cache.store(tokenCacheEntry{Token: token, ExpiresAt: jwtExpiry(token)}); err != nil {
+			tflog.Warn(ctx, "cant cache token", map[string]interface{}{"dir": cache.dir, "error": err.Error()})
+		}
+		return token, nil
+	}
+}
+
+type diskTokenCache struct {
+	dir string
+	key string
+}
+
+func (c *diskTokenCache) path() string {
+	sum := sha256.Sum256([]byte("name\x00" + c.key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16])+".token")
+}
+
+// load treats unreadable, foreign or too permissive files as cache miss.
+func (c *diskTokenCache) load() (tokenCacheEntry, bool) {
+	path := c.path()
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&0o077 != 0 {
+		return tokenCacheEntry{}, false
+	}
+	serialized, err := os.ReadFile(path)
+	if err != nil {
+		return tokenCacheEntry{}, false
+	}
+	var entry tokenCacheEntry
+	if err := json.Unmarshal(serialized, &entry); err != nil || entry.Token == "" {
+		return tokenCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// store writes entry atomically, file is created with 0600 permissions.
+func (c *diskTokenCache) store(entry tokenCacheEntry) error {
+	serialized, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(c.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(serialized); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), c.path())
+}
+
+// jwtExpiry returns exp claim of jwt without verifying it, zero time for other tokens.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingTokenSource returns token and counts calls.
+func countingTokenSource(token string, calls *int) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		*calls++
+		return token, nil
+	}
+}
+
+func jwtWithExpiry(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, exp.Unix())))
+	return "header." + payload + ".signature"
+}
+
+func TestDiskCachedTokenSourceHit(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	first := DiskCachedTokenSource(dir, "key", countingTokenSource("token", &calls))
+	if token, err := first(context.Background()); err != nil || token != "token" {
+		t.Fatalf("first run got %q, %v", token, err)
+	}
+
+	// next process reads token stored by previous one
+	second := DiskCachedTokenSource(dir, "key", countingTokenSource("other", &calls))
+	if token, err := second(context.Background()); err != nil || token != "token" {
+		t.Fatalf("second run got %q, %v, want cached token", token, err)
+	}
+	if calls != 1 {
+		t.Fatalf("source called %d times, want 1", calls)
+	}
+	// refresh after 401 bypasses cache
+	if token, err := second(context.Background()); err != nil || token != "other" {
+		t.Fatalf("refresh got %q, %v, want token from source", token, err)
+	}
+}
+
+func TestDiskCachedTokenSourceMiss(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		// prepare runs after first process cached token
+		prepare func(t *testing.T, dir string)
+		key     string
+	}{
+		{
+			name:  "other key",
+			token: "token",
+			key:   "other",
+		},
+		{
+			name:  "expired jwt",
+			token: jwtWithExpiry(time.Now().Add(-time.Minute)),
+			key:   "key",
+		},
+		{
+			name:  "jwt expiring within margin",
+			token: jwtWithExpiry(time.Now().Add(tokenCacheExpiryMargin / 2)),
+			key:   "key",
+		},
+		{
+			name:  "too permissive file",
+			token: "token",
+			prepare: func(t *testing.T, dir string) {
+				files, err := filepath.Glob(filepath.Join(dir, "*.token"))
+				if err != nil || len(files) != 1 {
+					t.Fatalf("want one cache file, got %v, %v", files, err)
+				}
+				if err := os.Chmod(files[0], 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			key: "key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			calls := 0
+			if _, err := DiskCachedTokenSource(dir, "key", countingTokenSource(tt.token, &calls))(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if tt.prepare != nil {
+				tt.prepare(t, dir)
+			}
+			token, err := DiskCachedTokenSource(dir, tt.key, countingTokenSource("fresh", &calls))(context.Background())
+			if err != nil || token != "fresh" {
+				t.Fatalf("got %q, %v, want fresh token", token, err)
+			}
+			if calls != 2 {
+				t.Fatalf("source called %d times, want 2", calls)
+			}
+		})
+	}
+}
+
+func TestDiskCachedTokenSourceValidJwtHit(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	token := jwtWithExpiry(time.Now().Add(time.Hour))
+	if _, err := DiskCachedTokenSource(dir, "key", countingTokenSource(token, &calls))(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DiskCachedTokenSource(dir, "key", countingTokenSource("fresh", &calls))(context.Background())
+	if err != nil || got != token {
+		t.Fatalf("got %q, %v, want cached jwt", got, err)
+	}
+}
+
+func TestDiskCachedTokenSourceFilePermissions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	calls := 0
+	if _, err := DiskCachedTokenSource(dir, "key", countingTokenSource("token", &calls))(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("want one cache file, got %v, %v", files, err)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("cache file permissions %o, want 600", perm)
+	}
+}
+
+func TestDiskCachedTokenSourceStoreFailure(t *testing.T) {
+	// cache dir cant be created under regular file
+	parent := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(parent, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	token, err := DiskCachedTokenSource(filepath.Join(parent, "cache"), "key", countingTokenSource("token", &calls))(context.Background())
+	if err != nil || token != "token" {
+		t.Fatalf("got %q, %v, want token despite cache failure", token, err)
+	}
+}
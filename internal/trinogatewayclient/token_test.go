@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// tokenScript writes stub command printing token-1, token-2 and so on, one per run.
+func tokenScript(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub token command is shell script")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "token.sh")
+	content := "#!/bin/sh\n" +
+		"count=$(cat " + filepath.Join(dir, "count") + " 2>/dev/null || echo 0)\n" +
+		"count=$((count + 1))\n" +
+		"echo $count > " + filepath.Join(dir, "count") + "\n" +
+		"echo token-$count\n"
+	if err := os.WriteFile(script, []byte(content), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return script
+}
+
+func TestCommandTokenRefresh(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		// first token is already expired
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client, err := NewTrinoGatewayClient(server.URL, nil, WithBearerTokenSource(CommandTokenSource(tokenScript(t))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAllBackends(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"Bearer token-1", "Bearer token-2", "Bearer token-2"}
+	if strings.Join(authorizations, ",") != strings.Join(want, ",") {
+		t.Fatalf("got authorizations %v, want %v", authorizations, want)
+	}
+}
+
+func TestCommandTokenSourceFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub token command is shell command")
+	}
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{name: "exit code", command: "echo expired >&2; exit 1", want: "stderr: expired"},
+		{name: "empty token", command: "echo", want: "empty token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CommandTokenSource(tt.command)(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got %v, want error with %q", err, tt.want)
+			}
+		})
+	}
+}
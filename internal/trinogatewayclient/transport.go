@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const (
+	// same as in http.DefaultTransport
+	defaultDialTimeout = 30 * time.Second
+	defaultKeepAlive   = 30 * time.Second
+)
+
+type transportConfig struct {
+	proxyUrl    *url.URL
+	dialTimeout time.Duration
+	keepAlive   time.Duration
+}
+
+// WithProxyUrl sends requests through proxy. socks5:// and socks5h:// urls use SOCKS5,
+// others are handled as http proxy.
+func WithProxyUrl(proxyUrl *url.URL) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.transportConfig.proxyUrl = proxyUrl
+	}
+}
+
+// WithDialer configures tcp connect timeout and keep-alive period.
+func WithDialer(dialTimeout time.Duration, keepAlive time.Duration) Option {
+	return func(tg *trinoGatewayClientHttpImpl) {
+		tg.transportConfig.dialTimeout = dialTimeout
+		tg.transportConfig.keepAlive = keepAlive
+	}
+}
+
+func (c *transportConfig) dialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout:   c.dialTimeout,
+		KeepAlive: c.keepAlive,
+	}
+}
+
+func (c *transportConfig) newTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := c.dialer()
+	transport.DialContext = dialer.DialContext
+	if c.proxyUrl == nil {
+		return transport, nil
+	}
+
+	switch c.proxyUrl.Scheme {
+	case "socks5", "socks5h":
+		socksDialer, err := proxy.FromURL(c.proxyUrl, dialer)
+		if err != nil {
+			return nil, fmt.Errorf("cant create socks5 dialer: %w", err)
+		}
+		contextDialer, ok := socksDialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("socks5 dialer does not support context")
+		}
+		transport.Proxy = nil
+		transport.DialContext = contextDialer.DialContext
+	default:
+		transport.Proxy = http.ProxyURL(c.proxyUrl)
+	}
+	return transport, nil
+}
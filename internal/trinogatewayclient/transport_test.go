@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package trinogatewayclient
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// socks5Stub is no-auth SOCKS5 server supporting CONNECT only, it counts proxied connections.
+func socks5Stub(t *testing.T, connections *atomic.Int32) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				target, err := socks5Handshake(conn)
+				if err != nil {
+					return
+				}
+				upstream, err := net.Dial("tcp", target)
+				if err != nil {
+					return
+				}
+				defer upstream.Close()
+				connections.Add(1)
+				// succeeded, bound to 0.0.0.0:0
+				if _, err := conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+					return
+				}
+				go func() { _, _ = io.Copy(upstream, conn) }()
+				_, _ = io.Copy(conn, upstream)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// socks5Handshake reads greeting and CONNECT request, returns target address.
+func socks5Handshake(conn net.Conn) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return "", err
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return "", err
+	}
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", err
+	}
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	case 3:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return "", err
+		}
+		name := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	default:
+		return "", io.ErrUnexpectedEOF
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+func TestSocks5Proxy(t *testing.T) {
+	for _, scheme := range []string{"socks5", "socks5h"} {
+		t.Run(scheme, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`[{"name":"trino-1"}]`))
+			}))
+			defer server.Close()
+			var connections atomic.Int32
+			proxyUrl := &url.URL{Scheme: scheme, Host: socks5Stub(t, &connections)}
+
+			client, err := NewTrinoGatewayClient(server.URL, nil, WithProxyUrl(proxyUrl))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+			backends, err := client.GetAllBackends(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(backends) != 1 || backends[0].Name != "trino-1" {
+				t.Fatalf("got backends %v", backends)
+			}
+			if got := connections.Load(); got != 1 {
+				t.Fatalf("got %d proxied connections, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDialer(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		wantTimeout   time.Duration
+		wantKeepAlive time.Duration
+	}{
+		{name: "defaults", wantTimeout: defaultDialTimeout, wantKeepAlive: defaultKeepAlive},
+		{name: "configured", opts: []Option{WithDialer(5*time.Second, time.Minute)}, wantTimeout: 5 * time.Second, wantKeepAlive: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewTrinoGatewayClient("http://gateway", nil, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dialer := client.(*trinoGatewayClientHttpImpl).transportConfig.dialer()
+			if dialer.Timeout != tt.wantTimeout || dialer.KeepAlive != tt.wantKeepAlive {
+				t.Fatalf("got timeout %s and keep-alive %s, want %s and %s", dialer.Timeout, dialer.KeepAlive, tt.wantTimeout, tt.wantKeepAlive)
+			}
+		})
+	}
+}
+
+func TestClientsDoNotShareTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	var connections atomic.Int32
+	proxied, err := NewTrinoGatewayClient(server.URL, nil, WithProxyUrl(&url.URL{Scheme: "socks5", Host: socks5Stub(t, &connections)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	direct, err := NewTrinoGatewayClient(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxiedTransport := proxied.(*trinoGatewayClientHttpImpl).transport
+	directTransport := direct.(*trinoGatewayClientHttpImpl).transport
+	if proxiedTransport == directTransport || directTransport == http.DefaultTransport {
+		t.Fatal("clients share transport")
+	}
+
+	if _, err := direct.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := connections.Load(); got != 0 {
+		t.Fatalf("direct client made %d proxied connections", got)
+	}
+	if _, err := proxied.GetAllBackends(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := connections.Load(); got != 1 {
+		t.Fatalf("got %d proxied connections, want 1", got)
+	}
+}